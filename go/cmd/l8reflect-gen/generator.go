@@ -0,0 +1,466 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"sort"
+	"strings"
+
+	"github.com/saichler/l8reflect/go/reflect/helping"
+)
+
+// typeKind classifies a field type for code generation.
+type typeKind int
+
+const (
+	kindBasic typeKind = iota
+	kindStruct
+	kindPtr
+	kindSlice
+	kindArray
+	kindMap
+	kindFunc
+	kindChan
+	kindFallback
+)
+
+// basicTypes lists the predeclared types copied and compared by value.
+var basicTypes = map[string]bool{
+	"bool": true, "string": true, "byte": true, "rune": true, "uintptr": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true, "complex64": true, "complex128": true,
+}
+
+// typeInfo describes a field type for code generation.
+type typeInfo struct {
+	kind typeKind
+	// expr is the type's source, e.g. "[]*Port" or "Status"
+	expr string
+	// name is the struct name of kindStruct types
+	name string
+	// isBytes marks byte slices, compared atomically like the reflective DeepEqual
+	isBytes bool
+	elem    *typeInfo
+}
+
+// generator emits the clone and equal functions of a package's struct types.
+type generator struct {
+	pkg  *pkgInfo
+	buff strings.Builder
+	// resolved caches the type info of the package's named types
+	resolved map[string]*typeInfo
+	// queue holds the struct types referenced but not yet emitted
+	queue   []string
+	emitted map[string]bool
+	imports map[string]bool
+	// usesClone and usesEqual mark the use of the reflective fallbacks
+	usesClone bool
+	usesEqual bool
+}
+
+func newGenerator(pkg *pkgInfo) *generator {
+	return &generator{pkg: pkg, resolved: make(map[string]*typeInfo), emitted: make(map[string]bool), imports: make(map[string]bool)}
+}
+
+// generate returns the source of the generated file for the requested types.
+func (this *generator) generate(types []string) ([]byte, error) {
+	pkgName := ""
+	for _, name := range types {
+		spec, ok := this.pkg.types[name]
+		if !ok {
+			return nil, errors.New("type " + name + " not found")
+		}
+		if _, ok = spec.Type.(*ast.StructType); !ok || spec.TypeParams != nil {
+			return nil, errors.New("type " + name + " is not a non generic struct")
+		}
+		if pkgName == "" {
+			pkgName = this.pkg.packages[name]
+		} else if pkgName != this.pkg.packages[name] {
+			return nil, errors.New("types " + strings.Join(types, ",") + " are declared in different packages")
+		}
+	}
+
+	for _, name := range types {
+		this.emitMethods(name)
+		this.enqueue(name)
+	}
+	for len(this.queue) > 0 {
+		name := this.queue[0]
+		this.queue = this.queue[1:]
+		this.emitStruct(name)
+	}
+	this.emitFallbacks()
+
+	header := &strings.Builder{}
+	header.WriteString("// Code generated by l8reflect-gen. DO NOT EDIT.\n\n")
+	header.WriteString("package " + pkgName + "\n\n")
+	if len(this.imports) > 0 {
+		imports := make([]string, 0, len(this.imports))
+		for imp := range this.imports {
+			imports = append(imports, imp)
+		}
+		sort.Slice(imports, func(i, j int) bool {
+			iStd := !strings.Contains(imports[i], ".")
+			jStd := !strings.Contains(imports[j], ".")
+			if iStd != jStd {
+				return iStd
+			}
+			return imports[i] < imports[j]
+		})
+		header.WriteString("import (\n")
+		for i, imp := range imports {
+			// standard library imports first, separated from the module imports
+			if i > 0 && strings.Contains(imp, ".") && !strings.Contains(imports[i-1], ".") {
+				header.WriteString("\n")
+			}
+			header.WriteString("\t\"" + imp + "\"\n")
+		}
+		header.WriteString(")\n\n")
+	}
+	return []byte(header.String() + this.buff.String()), nil
+}
+
+// p writes a formatted line to the generated code.
+func (this *generator) p(format string, args ...interface{}) {
+	fmt.Fprintf(&this.buff, format, args...)
+	this.buff.WriteString("\n")
+}
+
+// enqueue schedules the helpers of a struct type to be emitted.
+func (this *generator) enqueue(name string) {
+	if this.emitted[name] {
+		return
+	}
+	this.emitted[name] = true
+	this.queue = append(this.queue, name)
+}
+
+// emitMethods emits the DeepClone and DeepEqual methods of a requested type.
+func (this *generator) emitMethods(name string) {
+	this.p("// DeepClone returns a deep copy of the %s, see cloning.DeepCloner.", name)
+	this.p("func (this *%s) DeepClone() interface{} {", name)
+	this.p("return l8reflectClone%s(this)", name)
+	this.p("}")
+	this.p("")
+	this.p("// DeepEqual returns true if other is a *%s deeply equal to this one, see cloning.DeepEqualer.", name)
+	this.p("func (this *%s) DeepEqual(other interface{}) bool {", name)
+	this.p("that, ok := other.(*%s)", name)
+	this.p("if !ok {")
+	this.p("return false")
+	this.p("}")
+	this.p("return l8reflectEqual%s(this, that)", name)
+	this.p("}")
+	this.p("")
+}
+
+// emitStruct emits the clone and equal helpers of a struct type.
+func (this *generator) emitStruct(name string) {
+	fields := this.fields(name)
+
+	this.p("func l8reflectClone%s(src *%s) *%s {", name, name, name)
+	this.p("if src == nil {")
+	this.p("return nil")
+	this.p("}")
+	this.p("dst := &%s{}", name)
+	for _, field := range fields {
+		this.cloneStmt(field.info, "dst."+field.name, "src."+field.name, 0)
+	}
+	this.p("return dst")
+	this.p("}")
+	this.p("")
+
+	this.p("func l8reflectEqual%s(a, b *%s) bool {", name, name)
+	this.p("if a == nil || b == nil {")
+	this.p("return a == b")
+	this.p("}")
+	for _, field := range fields {
+		this.equalStmt(field.info, "a."+field.name, "b."+field.name, 0)
+	}
+	this.p("return true")
+	this.p("}")
+	this.p("")
+}
+
+// field is a struct field taking part in cloning and comparison.
+type field struct {
+	name string
+	info *typeInfo
+}
+
+// fields returns the fields of a struct type not skipped by helping.IgnoreName.
+func (this *generator) fields(name string) []field {
+	structType := this.pkg.types[name].Type.(*ast.StructType)
+	result := make([]field, 0)
+	for _, astField := range structType.Fields.List {
+		names := make([]string, 0, len(astField.Names))
+		for _, ident := range astField.Names {
+			names = append(names, ident.Name)
+		}
+		if len(names) == 0 {
+			names = append(names, embeddedName(astField.Type))
+		}
+		for _, fieldName := range names {
+			if fieldName == "" || helping.IgnoreName(fieldName) {
+				continue
+			}
+			result = append(result, field{name: fieldName, info: this.resolve(astField.Type)})
+		}
+	}
+	return result
+}
+
+// embeddedName returns the field name of an embedded field.
+func embeddedName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	}
+	return ""
+}
+
+// resolve returns the type info of a field type expression.
+func (this *generator) resolve(expr ast.Expr) *typeInfo {
+	info := &typeInfo{kind: kindFallback, expr: this.pkg.render(expr)}
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return this.resolveIdent(t.Name)
+	case *ast.StarExpr:
+		info.kind = kindPtr
+		info.elem = this.resolve(t.X)
+	case *ast.ArrayType:
+		info.elem = this.resolve(t.Elt)
+		if t.Len == nil {
+			info.kind = kindSlice
+			info.isBytes = info.elem.expr == "byte" || info.elem.expr == "uint8"
+		} else {
+			info.kind = kindArray
+		}
+	case *ast.MapType:
+		info.kind = kindMap
+		info.elem = this.resolve(t.Value)
+	case *ast.FuncType:
+		info.kind = kindFunc
+	case *ast.ChanType:
+		info.kind = kindChan
+	}
+	return info
+}
+
+// resolveIdent returns the type info of a predeclared or package level named type.
+// Named types keep their name and take the kind of their underlying type.
+func (this *generator) resolveIdent(name string) *typeInfo {
+	if basicTypes[name] {
+		return &typeInfo{kind: kindBasic, expr: name}
+	}
+	info, ok := this.resolved[name]
+	if ok {
+		return info
+	}
+	info = &typeInfo{kind: kindFallback, expr: name}
+	this.resolved[name] = info
+	spec, ok := this.pkg.types[name]
+	if !ok || spec.TypeParams != nil {
+		return info
+	}
+	if _, isStruct := spec.Type.(*ast.StructType); isStruct {
+		info.kind = kindStruct
+		info.name = name
+		this.enqueue(name)
+		return info
+	}
+	underlying := this.resolve(spec.Type)
+	if underlying.kind == kindStruct && spec.Assign == 0 {
+		// a named type defined from another struct type has no generated helpers
+		return info
+	}
+	*info = *underlying
+	if spec.Assign == 0 {
+		info.expr = name
+	}
+	return info
+}
+
+// cloneStmt emits the statements cloning src into dst.
+func (this *generator) cloneStmt(info *typeInfo, dst, src string, depth int) {
+	switch info.kind {
+	case kindBasic, kindFunc:
+		this.p("%s = %s", dst, src)
+	case kindStruct:
+		this.p("%s = *l8reflectClone%s(&%s)", dst, info.name, src)
+	case kindPtr:
+		if info.elem.kind == kindStruct {
+			this.p("%s = l8reflectClone%s(%s)", dst, info.elem.name, src)
+			return
+		}
+		this.p("if %s != nil {", src)
+		this.p("var p%d %s", depth, info.elem.expr)
+		this.cloneStmt(info.elem, fmt.Sprintf("p%d", depth), "(*"+src+")", depth+1)
+		this.p("%s = &p%d", dst, depth)
+		this.p("}")
+	case kindSlice:
+		this.p("if %s != nil {", src)
+		this.p("%s = make(%s, len(%s))", dst, info.expr, src)
+		if info.elem.kind == kindBasic {
+			this.p("copy(%s, %s)", dst, src)
+		} else {
+			this.cloneElems(info, dst, src, depth)
+		}
+		this.p("}")
+	case kindArray:
+		if info.elem.kind == kindBasic {
+			this.p("%s = %s", dst, src)
+			return
+		}
+		this.cloneElems(info, dst, src, depth)
+	case kindMap:
+		this.p("if %s != nil {", src)
+		this.p("%s = make(%s, len(%s))", dst, info.expr, src)
+		this.p("for k%d, v%d := range %s {", depth, depth, src)
+		if info.elem.kind == kindBasic {
+			this.p("%s[k%d] = v%d", dst, depth, depth)
+		} else {
+			this.p("var c%d %s", depth, info.elem.expr)
+			this.cloneStmt(info.elem, fmt.Sprintf("c%d", depth), fmt.Sprintf("v%d", depth), depth+1)
+			this.p("%s[k%d] = c%d", dst, depth, depth)
+		}
+		this.p("}")
+		this.p("}")
+	case kindChan:
+		this.p("if %s != nil {", src)
+		this.p("%s = make(%s)", dst, info.expr)
+		this.p("}")
+	default:
+		this.usesClone = true
+		this.p("%s = l8reflectClone(%s)", dst, src)
+	}
+}
+
+// cloneElems emits the loop cloning the elements of a slice or array.
+func (this *generator) cloneElems(info *typeInfo, dst, src string, depth int) {
+	index := fmt.Sprintf("i%d", depth)
+	this.p("for %s := range %s {", index, src)
+	this.cloneStmt(info.elem, dst+"["+index+"]", src+"["+index+"]", depth+1)
+	this.p("}")
+}
+
+// equalStmt emits the statements returning false if a and b are not deeply equal.
+func (this *generator) equalStmt(info *typeInfo, a, b string, depth int) {
+	switch info.kind {
+	case kindBasic:
+		this.p("if %s != %s {", a, b)
+		this.p("return false")
+		this.p("}")
+	case kindStruct:
+		this.p("if !l8reflectEqual%s(&%s, &%s) {", info.name, a, b)
+		this.p("return false")
+		this.p("}")
+	case kindPtr:
+		if info.elem.kind == kindStruct {
+			this.p("if !l8reflectEqual%s(%s, %s) {", info.elem.name, a, b)
+			this.p("return false")
+			this.p("}")
+			return
+		}
+		this.nilCheck(a, b, "")
+		this.p("if %s != nil {", a)
+		this.equalStmt(info.elem, "(*"+a+")", "(*"+b+")", depth+1)
+		this.p("}")
+	case kindSlice:
+		if info.isBytes {
+			this.imports["bytes"] = true
+			this.nilCheck(a, b, fmt.Sprintf(" || !bytes.Equal(%s, %s)", a, b))
+			return
+		}
+		this.nilCheck(a, b, fmt.Sprintf(" || len(%s) != len(%s)", a, b))
+		this.equalElems(info, a, b, depth)
+	case kindArray:
+		if info.elem.kind == kindBasic {
+			this.p("if %s != %s {", a, b)
+			this.p("return false")
+			this.p("}")
+			return
+		}
+		this.equalElems(info, a, b, depth)
+	case kindMap:
+		this.nilCheck(a, b, fmt.Sprintf(" || len(%s) != len(%s)", a, b))
+		this.p("for k%d, av%d := range %s {", depth, depth, a)
+		this.p("bv%d, ok := %s[k%d]", depth, b, depth)
+		this.p("if !ok {")
+		this.p("return false")
+		this.p("}")
+		this.equalStmt(info.elem, fmt.Sprintf("av%d", depth), fmt.Sprintf("bv%d", depth), depth+1)
+		this.p("}")
+	case kindFunc, kindChan:
+		this.nilCheck(a, b, "")
+	default:
+		this.usesEqual = true
+		this.p("if !l8reflectDeepEqual.Equal(%s, %s) {", a, b)
+		this.p("return false")
+		this.p("}")
+	}
+}
+
+// nilCheck emits the statement returning false if only one of a and b is nil,
+// or if the additional condition holds.
+func (this *generator) nilCheck(a, b, condition string) {
+	this.p("if (%s == nil) != (%s == nil)%s {", a, b, condition)
+	this.p("return false")
+	this.p("}")
+}
+
+// equalElems emits the loop comparing the elements of a slice or array.
+func (this *generator) equalElems(info *typeInfo, a, b string, depth int) {
+	index := fmt.Sprintf("i%d", depth)
+	this.p("for %s := range %s {", index, a)
+	this.equalStmt(info.elem, a+"["+index+"]", b+"["+index+"]", depth+1)
+	this.p("}")
+}
+
+// emitFallbacks emits the reflective helpers used for the types the generator cannot handle.
+func (this *generator) emitFallbacks() {
+	if !this.usesClone && !this.usesEqual {
+		return
+	}
+	this.imports["github.com/saichler/l8reflect/go/reflect/cloning"] = true
+	if this.usesClone {
+		this.imports["reflect"] = true
+		this.p("// l8reflectCloner clones the values the generated code cannot clone without reflection.")
+		this.p("var l8reflectCloner = cloning.NewCloner()")
+		this.p("")
+		this.p("// l8reflectClone clones a value with the reflective Cloner, keeping its static type.")
+		this.p("func l8reflectClone[T any](src T) T {")
+		this.p("var dst T")
+		this.p("cloned := reflect.ValueOf(l8reflectCloner.Clone(src))")
+		this.p("if cloned.IsValid() {")
+		this.p("target := reflect.ValueOf(&dst).Elem()")
+		this.p("target.Set(cloned.Convert(target.Type()))")
+		this.p("}")
+		this.p("return dst")
+		this.p("}")
+		this.p("")
+	}
+	if this.usesEqual {
+		this.p("// l8reflectDeepEqual compares the values the generated code cannot compare without reflection.")
+		this.p("var l8reflectDeepEqual = cloning.NewDeepEqual()")
+		this.p("")
+	}
+}
@@ -0,0 +1,80 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command l8reflect-gen generates reflection-free DeepClone and DeepEqual methods
+// for struct types. The cloning.Cloner and cloning.DeepEqual dispatch to them
+// automatically, through the cloning.DeepCloner and cloning.DeepEqualer interfaces.
+//
+// Usage, typically from a go:generate directive in the package declaring the types:
+//
+//	//go:generate go run github.com/saichler/l8reflect/go/cmd/l8reflect-gen -type Device,Port
+//
+// The generated code skips the same fields as cloning.SkipFieldByName and has the same
+// semantics as the reflective Cloner and DeepEqual with their default field policy.
+// Struct types of the package referenced by the requested types get unexported helpers.
+// Types declared in other packages, interfaces and generic instantiations fall back
+// to the reflective Cloner and DeepEqual.
+//
+// Unlike the reflective Cloner, the generated code does not track visited pointers,
+// so it must only be generated for types whose instances are trees (no cycles),
+// and shared pointers are cloned into separate copies.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma separated list of struct type names, required")
+	output := flag.String("output", "", "output file name, default <first type>_l8reflect.go")
+	dir := flag.String("dir", ".", "directory of the package declaring the types")
+	flag.Parse()
+
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	types := strings.Split(*typeNames, ",")
+	if *output == "" {
+		*output = strings.ToLower(types[0]) + "_l8reflect.go"
+	}
+
+	err := run(*dir, *output, types)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "l8reflect-gen:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the package in dir, generates the code for the types and writes it to output.
+func run(dir, output string, types []string) error {
+	pkg, err := parsePackage(dir, output)
+	if err != nil {
+		return err
+	}
+	gen := newGenerator(pkg)
+	src, err := gen.generate(types)
+	if err != nil {
+		return err
+	}
+	formatted, err := format.Source(src)
+	if err != nil {
+		return fmt.Errorf("formatting generated code: %v", err)
+	}
+	return os.WriteFile(filepath.Join(dir, output), formatted, 0644)
+}
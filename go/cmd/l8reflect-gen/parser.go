@@ -0,0 +1,76 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// pkgInfo holds the type declarations of the parsed package.
+type pkgInfo struct {
+	fset *token.FileSet
+	// types maps the type names to their declarations
+	types map[string]*ast.TypeSpec
+	// packages maps the type names to the name of the package declaring them
+	packages map[string]string
+}
+
+// parsePackage parses the Go files in dir, test files included, except the output file.
+func parsePackage(dir, output string) (*pkgInfo, error) {
+	pkg := &pkgInfo{fset: token.NewFileSet(), types: make(map[string]*ast.TypeSpec), packages: make(map[string]string)}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || name == output {
+			continue
+		}
+		file, err := parser.ParseFile(pkg.fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				pkg.types[typeSpec.Name.Name] = typeSpec
+				pkg.packages[typeSpec.Name.Name] = file.Name.Name
+			}
+		}
+	}
+	if len(pkg.types) == 0 {
+		return nil, errors.New("no type declarations found in " + dir)
+	}
+	return pkg, nil
+}
+
+// render returns the source of a type expression.
+func (this *pkgInfo) render(expr ast.Expr) string {
+	buff := &bytes.Buffer{}
+	printer.Fprint(buff, this.fset, expr)
+	return buff.String()
+}
@@ -0,0 +1,392 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains partial cloning by property path masks.
+// A mask is built from property paths such as "device.info" or "device.ports<*>.status"
+// and only the masked subtrees are copied into the new instance.
+
+package cloning
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/saichler/l8reflect/go/reflect/helping"
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8types/go/types/l8reflect"
+)
+
+// anyKey is the mask key selecting every element of a slice, array or map.
+const anyKey = "*"
+
+// cloneMask is a node of the mask tree built from property paths.
+type cloneMask struct {
+	// full marks the subtree as fully cloned
+	full bool
+	// fields maps the lowercase field names to their masks
+	fields map[string]*cloneMask
+	// elems maps the element keys of a container to their masks
+	elems map[string]*cloneMask
+}
+
+func newCloneMask() *cloneMask {
+	return &cloneMask{fields: make(map[string]*cloneMask), elems: make(map[string]*cloneMask)}
+}
+
+// CloneMask clones only the subtrees selected by the given property paths.
+// Paths use the property path grammar, starting with the lowercase type name, e.g.
+// "device.info" or "device.ports<*>.status". A key selects a single map/slice element
+// ("ports<{2}0>" or "ports<0>"), "*" or no key selects all elements.
+// Keys of the selected elements are preserved, everything else is left zero.
+// A keyed element also gets the subtrees selected for all elements of its container.
+// Returns an error if a path does not match the type of the given instance, selects a
+// field the Cloner skips or, when SetIntrospector was called, a field that is not in the
+// node tree, or a *LimitError if the instance exceeds the limits set by SetLimits.
+func (this *Cloner) CloneMask(any interface{}, paths ...string) (result interface{}, err error) {
+	if any == nil {
+		return nil, nil
+	}
+	value := reflect.ValueOf(any)
+	mask := newCloneMask()
+	for _, path := range paths {
+		err := this.addMask(mask, value.Type(), path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	mask.merge()
+	stopLoop := newCloneLoop(false)
+	this.limitLoop(stopLoop, value)
+	defer recoverLimit(&err)
+	valueClone := this.cloneMasked(value, mask, stopLoop)
+	if !valueClone.IsValid() {
+		return nil, nil
+	}
+	return valueClone.Interface(), nil
+}
+
+// SetIntrospector makes CloneMask validate its paths against the introspector's node
+// tree, so a path to a field the node tree leaves out is rejected. nil turns it off.
+func (this *Cloner) SetIntrospector(introspector ifs.IIntrospector) {
+	this.introspector = introspector
+}
+
+// addMask parses a property path and adds it to the mask, validating it against the type
+// and the node tree.
+func (this *Cloner) addMask(mask *cloneMask, typ reflect.Type, path string) error {
+	segments, err := maskSegments(path)
+	if err != nil {
+		return err
+	}
+	root := strings.ToLower(helping.CanonicalTypeName(elemType(typ).Name()))
+	if segments[0][0] != root {
+		return errors.New("Path " + path + " does not start with type " + root)
+	}
+	node, err := this.maskNode(elemType(typ))
+	if err != nil {
+		return err
+	}
+	for _, segment := range segments[1:] {
+		if mask.full {
+			return nil
+		}
+		typ = elemType(typ)
+		if typ.Kind() != reflect.Struct {
+			return errors.New("Unknown attribute " + segment[0] + " in path " + path)
+		}
+		field, ok := fieldByLowerName(typ, segment[0])
+		if !ok {
+			return errors.New("Unknown attribute " + segment[0] + " in path " + path)
+		}
+		if this.fieldPolicy.SkipField(typ, field) {
+			return errors.New("Attribute " + segment[0] + " in path " + path + " is skipped")
+		}
+		if node != nil {
+			node, ok = this.attributeNode(node, field.Name)
+			if !ok {
+				return errors.New("Attribute " + segment[0] + " in path " + path + " is not in the node tree")
+			}
+		}
+		typ = elemType(field.Type)
+		mask = mask.child(mask.fields, segment[0])
+		isContainer := typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array || typ.Kind() == reflect.Map
+		if segment[1] != "" {
+			if !isContainer {
+				return errors.New("Attribute " + segment[0] + " in path " + path + " has no keys")
+			}
+			mask = mask.child(mask.elems, unprefixedKey(segment[1]))
+		}
+		if isContainer {
+			typ = typ.Elem()
+		}
+	}
+	mask.full = true
+	return nil
+}
+
+// maskNode returns the introspector's node of a struct type, inspecting the type if needed,
+// or nil if no introspector is set.
+func (this *Cloner) maskNode(typ reflect.Type) (*l8reflect.L8Node, error) {
+	if this.introspector == nil {
+		return nil, nil
+	}
+	// nested nodes of the same type are copies that may lack the decorators
+	node, ok := this.introspector.Node(helping.CanonicalTypeName(typ.Name()))
+	if ok {
+		return node, nil
+	}
+	return this.introspector.Inspect(reflect.New(typ).Interface())
+}
+
+// attributeNode returns the node of a field under a node. A struct node without
+// attributes is looked up by its type, as the node tree describes each type once.
+func (this *Cloner) attributeNode(node *l8reflect.L8Node, name string) (*l8reflect.L8Node, bool) {
+	if len(node.Attributes) == 0 && node.TypeName != "" {
+		typeNode, ok := this.introspector.Node(helping.CanonicalTypeName(node.TypeName))
+		if ok {
+			node = typeNode
+		}
+	}
+	attribute, ok := node.Attributes[name]
+	return attribute, ok
+}
+
+// merge adds to every keyed element mask the masks selecting all elements of its
+// container, so "ports<{2}1>.status" and "ports.name" select both fields of element 1.
+func (this *cloneMask) merge() {
+	if this.full {
+		return
+	}
+	all := &cloneMask{fields: this.fields, elems: map[string]*cloneMask{}}
+	if anyMask, ok := this.elems[anyKey]; ok {
+		all = all.union(anyMask)
+	}
+	for key, elemMask := range this.elems {
+		if key != anyKey && (len(all.fields) > 0 || len(all.elems) > 0 || all.full) {
+			this.elems[key] = elemMask.union(all)
+		}
+	}
+	for _, fieldMask := range this.fields {
+		fieldMask.merge()
+	}
+	for _, elemMask := range this.elems {
+		elemMask.merge()
+	}
+}
+
+// union returns a new mask selecting everything selected by this mask or the other.
+func (this *cloneMask) union(other *cloneMask) *cloneMask {
+	mask := newCloneMask()
+	mask.full = this.full || other.full
+	for _, source := range []*cloneMask{this, other} {
+		for name, fieldMask := range source.fields {
+			mask.fields[name] = unionOf(mask.fields[name], fieldMask)
+		}
+		for key, elemMask := range source.elems {
+			mask.elems[key] = unionOf(mask.elems[key], elemMask)
+		}
+	}
+	return mask
+}
+
+// unionOf returns the union of two masks, the first of which may be nil.
+func unionOf(mask, other *cloneMask) *cloneMask {
+	if mask == nil {
+		return other.union(newCloneMask())
+	}
+	return mask.union(other)
+}
+
+// child returns the mask under the given name, creating it if needed.
+func (this *cloneMask) child(children map[string]*cloneMask, name string) *cloneMask {
+	mask, ok := children[name]
+	if !ok {
+		mask = newCloneMask()
+		children[name] = mask
+	}
+	return mask
+}
+
+// elem returns the mask of a container element with the given key.
+// A container mask without keys applies its field masks to every element.
+func (this *cloneMask) elem(key interface{}) *cloneMask {
+	return this.elemByKey(helping.KeyString(key))
+}
+
+// elemByKey returns the mask of a container element with the given encoded key.
+func (this *cloneMask) elemByKey(key string) *cloneMask {
+	if this.full {
+		return this
+	}
+	mask, ok := this.elems[unprefixedKey(key)]
+	if ok {
+		return mask
+	}
+	mask, ok = this.elems[anyKey]
+	if ok {
+		return mask
+	}
+	if len(this.fields) > 0 {
+		return this
+	}
+	return nil
+}
+
+// cloneMasked clones the parts of the value selected by the mask.
+func (this *Cloner) cloneMasked(value reflect.Value, mask *cloneMask, stopLoop *cloneLoop) reflect.Value {
+	if mask.full {
+		return this.clone(value, "", stopLoop)
+	}
+	if stopLoop.counter != nil {
+		defer stopLoop.checkLimits(value)()
+	}
+	if value.IsValid() {
+		hooked, ok := this.cloneHook(value, stopLoop)
+		if ok {
+			return hooked
+		}
+	}
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return value
+		}
+		newPtr := reflect.New(value.Elem().Type())
+		setCloned(newPtr.Elem(), this.cloneMasked(value.Elem(), mask, stopLoop))
+		return newPtr
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		return this.cloneMasked(value.Elem(), mask, stopLoop)
+	case reflect.Struct:
+		cloneStruct := reflect.New(value.Type()).Elem()
+		structType := value.Type()
+		for i := 0; i < structType.NumField(); i++ {
+			field := structType.Field(i)
+			if this.fieldPolicy.SkipField(structType, field) {
+				continue
+			}
+			fieldMask, ok := mask.fields[strings.ToLower(field.Name)]
+			if !ok {
+				continue
+			}
+			stopLoop.pushField(field.Name)
+			if fieldMask.full || this.isRedacted(structType, field) {
+				setCloned(cloneStruct.Field(i), this.cloneField(structType, field, value.Field(i), stopLoop))
+			} else {
+				setCloned(cloneStruct.Field(i), this.cloneMasked(value.Field(i), fieldMask, stopLoop))
+			}
+			stopLoop.pop()
+		}
+		return cloneStruct
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		newSlice := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		this.cloneMaskedElems(value, newSlice, mask, stopLoop)
+		return newSlice
+	case reflect.Array:
+		newArray := reflect.New(value.Type()).Elem()
+		this.cloneMaskedElems(value, newArray, mask, stopLoop)
+		return newArray
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		mapClone := reflect.MakeMap(value.Type())
+		for _, key := range value.MapKeys() {
+			elemMask := mask.elem(key.Interface())
+			if elemMask == nil {
+				continue
+			}
+			elemClone := reflect.New(value.Type().Elem()).Elem()
+			stopLoop.pushMapKey(key)
+			setCloned(elemClone, this.cloneMasked(value.MapIndex(key), elemMask, stopLoop))
+			stopLoop.pop()
+			mapClone.SetMapIndex(key, elemClone)
+		}
+		return mapClone
+	}
+	return this.clone(value, "", stopLoop)
+}
+
+// cloneMaskedElems clones the masked elements of a slice or array into target,
+// keeping each element at its original index.
+func (this *Cloner) cloneMaskedElems(value, target reflect.Value, mask *cloneMask, stopLoop *cloneLoop) {
+	for i := 0; i < value.Len(); i++ {
+		elemMask := mask.elem(i)
+		if elemMask == nil {
+			continue
+		}
+		stopLoop.pushIndex(i)
+		setCloned(target.Index(i), this.cloneMasked(value.Index(i), elemMask, stopLoop))
+		stopLoop.pop()
+	}
+}
+
+// maskSegments parses a property path into lowercase attribute name and mask key pairs.
+func maskSegments(path string) ([][2]string, error) {
+	pathSegments, err := helping.PathSegments(path)
+	if err != nil {
+		return nil, err
+	}
+	segments := make([][2]string, len(pathSegments))
+	for i, segment := range pathSegments {
+		segments[i] = [2]string{strings.ToLower(segment.Name), maskKey(segment.Key, segment.HasKey)}
+	}
+	return segments, nil
+}
+
+// maskKey returns the key of a path segment, an empty key ("<>") selects all elements.
+func maskKey(key string, hasKey bool) string {
+	if hasKey && key == "" {
+		return anyKey
+	}
+	return key
+}
+
+// unprefixedKey removes the type prefixes (e.g. "{24}") from an encoded key,
+// so masks match keys written with or without them.
+func unprefixedKey(key string) string {
+	tokens := helping.StructKeyFields(key)
+	for i, token := range tokens {
+		if strings.HasPrefix(token, "{") {
+			index := strings.Index(token, "}")
+			if index != -1 {
+				tokens[i] = token[index+1:]
+			}
+		}
+	}
+	return helping.JoinStructKey(tokens)
+}
+
+// elemType returns the type a pointer points to, or the type itself.
+func elemType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+// fieldByLowerName finds a struct field by its lowercase property name.
+func fieldByLowerName(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		if strings.ToLower(typ.Field(i).Name) == name {
+			return typ.Field(i), true
+		}
+	}
+	return reflect.StructField{}, false
+}
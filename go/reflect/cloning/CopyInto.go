@@ -0,0 +1,255 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains deep copying into an existing destination, so pooled objects
+// and objects referenced from other indexes can be refreshed in place.
+
+package cloning
+
+import (
+	"errors"
+	"reflect"
+)
+
+// CopyInto deep copies the contents of src into dst, an existing non nil pointer.
+// src is either a pointer of the same type as dst or a value of the type dst points to.
+// Existing sub-structs, slices and maps of dst are reused where possible: pointed-to structs
+// are refreshed in place, slices are resliced when their capacity allows and map entries
+// missing in src are deleted. A dst object shared by destinations copied from different
+// sources is reused for the first of them only, the others get clones. Fields skipped by the field policy keep their value in dst.
+// Returns a *LimitError if src exceeds the limits set by SetLimits, leaving dst partially copied.
+func (this *Cloner) CopyInto(dst, src interface{}) (err error) {
+	if dst == nil || src == nil {
+		return errors.New("CopyInto: destination and source must not be nil")
+	}
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() {
+		return errors.New("CopyInto: destination must be a non nil pointer")
+	}
+	srcValue := reflect.ValueOf(src)
+	stopLoop := newCloneLoop(false)
+	stopLoop.claims = newCopyClaims()
+	stopLoop.claims.claimMemory(dstValue.Pointer(), dstValue.Type().Elem().Size())
+	this.limitLoop(stopLoop, srcValue)
+	defer recoverLimit(&err)
+	if srcValue.Type() == dstValue.Type() {
+		if srcValue.IsNil() {
+			return errors.New("CopyInto: source must be a non nil pointer")
+		}
+		if srcValue.Pointer() == dstValue.Pointer() {
+			return nil
+		}
+		// references back to the source root are mapped to the destination root
+		stopLoop.loadOrStore(cloneKey{ptr: srcValue.Pointer(), typ: srcValue.Type()}, dstValue)
+		srcValue = srcValue.Elem()
+	}
+	if srcValue.Type() != dstValue.Type().Elem() {
+		return errors.New("CopyInto: cannot copy " + srcValue.Type().String() + " into " + dstValue.Type().String())
+	}
+	this.copyInto(dstValue.Elem(), srcValue, stopLoop)
+	return nil
+}
+
+// copyInto deep copies src into the settable dst of the same type, reusing dst's contents.
+func (this *Cloner) copyInto(dst, src reflect.Value, stopLoop *cloneLoop) {
+	if stopLoop.counter != nil {
+		defer stopLoop.checkLimits(src)()
+	}
+	hooked, ok := this.cloneHook(src, stopLoop)
+	if ok {
+		setCloned(dst, hooked)
+		return
+	}
+	switch src.Kind() {
+	case reflect.Struct:
+		structType := src.Type()
+		for i := 0; i < structType.NumField(); i++ {
+			field := structType.Field(i)
+			if this.fieldPolicy.SkipField(structType, field) {
+				continue
+			}
+			if this.isRedacted(structType, field) {
+				dst.Field(i).Set(this.redact(src.Field(i)))
+				continue
+			}
+			stopLoop.pushField(field.Name)
+			this.copyFieldInto(structType, field, dst.Field(i), src.Field(i), stopLoop)
+			stopLoop.pop()
+		}
+	case reflect.Ptr:
+		this.copyPtrInto(dst, src, stopLoop)
+	case reflect.Slice:
+		this.copySliceInto(dst, src, stopLoop)
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			stopLoop.pushIndex(i)
+			this.copyInto(dst.Index(i), src.Index(i), stopLoop)
+			stopLoop.pop()
+		}
+	case reflect.Map:
+		this.copyMapInto(dst, src, stopLoop)
+	case reflect.Interface:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return
+		}
+		if !dst.IsNil() && dst.Elem().Type() == src.Elem().Type() {
+			elem := reflect.New(dst.Elem().Type()).Elem()
+			elem.Set(dst.Elem())
+			this.copyInto(elem, src.Elem(), stopLoop)
+			dst.Set(elem)
+			return
+		}
+		setCloned(dst, this.clone(src.Elem(), "", stopLoop))
+	case reflect.Chan:
+		setCloned(dst, this.clone(src, "", stopLoop))
+	default:
+		dst.Set(src)
+	}
+}
+
+// copyFieldInto copies a struct field, cloning reference fields as Clone does.
+func (this *Cloner) copyFieldInto(structType reflect.Type, field reflect.StructField, dst, src reflect.Value, stopLoop *cloneLoop) {
+	if this.referencePolicy != nil {
+		isReference, _ := this.referencePolicy.Reference(structType, field)
+		if isReference {
+			setCloned(dst, this.cloneField(structType, field, src, stopLoop))
+			return
+		}
+	}
+	this.copyInto(dst, src, stopLoop)
+}
+
+// copyPtrInto copies the pointed-to value of src into the struct dst points to,
+// or clones it if dst is nil or already used for another source. Pointers already copied
+// are shared, as with Clone.
+func (this *Cloner) copyPtrInto(dst, src reflect.Value, stopLoop *cloneLoop) {
+	if src.IsNil() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return
+	}
+	key := cloneKey{ptr: src.Pointer(), typ: src.Type()}
+	exist, ok := stopLoop.load(key)
+	if ok {
+		dst.Set(exist)
+		return
+	}
+	if dst.IsNil() || dst.Pointer() == src.Pointer() ||
+		!stopLoop.claims.claimMemory(dst.Pointer(), dst.Type().Elem().Size()) {
+		dst.Set(this.clone(src, "", stopLoop))
+		return
+	}
+	current := reflect.New(dst.Type()).Elem()
+	current.Set(dst)
+	stopLoop.loadOrStore(key, current)
+	this.copyInto(dst.Elem(), src.Elem(), stopLoop)
+}
+
+// copySliceInto copies src into dst, reslicing dst when its capacity allows
+// and reusing its existing elements. A dst sharing src's backing array, or a backing
+// array already used for another source, is reallocated without reusing its elements. The elements past the new
+// length of a shrunk dst are cleared, so they do not keep their values alive.
+func (this *Cloner) copySliceInto(dst, src reflect.Value, stopLoop *cloneLoop) {
+	if src.IsNil() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return
+	}
+	target := dst
+	reuse := !dst.IsNil() && dst.Pointer() != src.Pointer() &&
+		stopLoop.claims.claimMemory(dst.Pointer(), uintptr(dst.Cap())*dst.Type().Elem().Size())
+	if !reuse || dst.Cap() < src.Len() {
+		target = reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		if reuse {
+			reflect.Copy(target, dst)
+		}
+	} else {
+		target = dst.Slice(0, src.Len())
+		zero := reflect.Zero(dst.Type().Elem())
+		for i := src.Len(); i < dst.Len(); i++ {
+			dst.Index(i).Set(zero)
+		}
+	}
+	if src.Type().Elem().Kind() == reflect.Uint8 {
+		reflect.Copy(target, src)
+	} else {
+		for i := 0; i < src.Len(); i++ {
+			stopLoop.pushIndex(i)
+			this.copyInto(target.Index(i), src.Index(i), stopLoop)
+			stopLoop.pop()
+		}
+	}
+	dst.Set(target)
+}
+
+// copyMapInto copies src into dst, reusing the values of the keys present in both
+// and deleting the keys missing in src. A dst that is the same map as src, or a map
+// already used for another source, is reallocated.
+func (this *Cloner) copyMapInto(dst, src reflect.Value, stopLoop *cloneLoop) {
+	if src.IsNil() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return
+	}
+	if dst.IsNil() || dst.Pointer() == src.Pointer() || !stopLoop.claims.claimMap(dst.Pointer()) {
+		dst.Set(reflect.MakeMapWithSize(dst.Type(), src.Len()))
+	}
+	for _, key := range dst.MapKeys() {
+		if !src.MapIndex(key).IsValid() {
+			dst.SetMapIndex(key, reflect.Value{})
+		}
+	}
+	for _, key := range src.MapKeys() {
+		elem := reflect.New(dst.Type().Elem()).Elem()
+		exist := dst.MapIndex(key)
+		if exist.IsValid() {
+			elem.Set(exist)
+		}
+		stopLoop.pushMapKey(key)
+		this.copyInto(elem, src.MapIndex(key), stopLoop)
+		stopLoop.pop()
+		dst.SetMapIndex(key, elem)
+	}
+}
+
+// copyClaims records the memory and maps of the destination reused by a CopyInto, so a
+// destination object shared by several destinations is refreshed from a single source.
+type copyClaims struct {
+	memory addrRanges
+	maps   map[uintptr]bool
+}
+
+// newCopyClaims creates an empty copyClaims.
+func newCopyClaims() *copyClaims {
+	return &copyClaims{memory: make(addrRanges), maps: make(map[uintptr]bool)}
+}
+
+// claimMemory claims the memory of size bytes from start, returning false if any of it
+// was already claimed. Zero sized memory is always claimed.
+func (this *copyClaims) claimMemory(start, size uintptr) bool {
+	if size == 0 {
+		return true
+	}
+	if this.memory.covered(start, start+size) > 0 {
+		return false
+	}
+	this.memory.add(start, start+size)
+	return true
+}
+
+// claimMap claims a map, returning false if it was already claimed.
+func (this *copyClaims) claimMap(ptr uintptr) bool {
+	if this.maps[ptr] {
+		return false
+	}
+	this.maps[ptr] = true
+	return true
+}
@@ -18,14 +18,22 @@
 // Key features:
 //   - Type-safe deep cloning of any Go data structure
 //   - Circular reference detection and handling
-//   - Customizable field filtering (skip fields by name patterns)
+//   - Optional preservation of shared maps, slices and pointers
+//   - Customizable field filtering via a pluggable helping.FieldPolicy
+//   - Per-type clone hooks via the DeepCloner interface and RegisterCloneFunc
+//   - Reference fields copied shallowly or by key via a helping.ReferencePolicy
+//   - Structural redaction of sensitive fields for logs and export
+//   - Optional parallel cloning of large slices and maps
+//   - Optional limits on the depth, elements and bytes of the cloned values
 //   - Support for all Go primitive and composite types
 package cloning
 
 import (
 	"reflect"
-	"strconv"
-	"strings"
+	"sync"
+
+	"github.com/saichler/l8reflect/go/reflect/helping"
+	"github.com/saichler/l8types/go/ifs"
 )
 
 // Cloner provides deep cloning functionality for Go data structures.
@@ -33,35 +41,142 @@ import (
 // circular references through pointer tracking.
 type Cloner struct {
 	// cloners maps each reflect.Kind to its corresponding cloning function
-	cloners map[reflect.Kind]func(reflect.Value, string, map[string]reflect.Value) reflect.Value
+	cloners map[reflect.Kind]func(reflect.Value, string, *cloneLoop) reflect.Value
+	// fieldPolicy decides which struct fields are not cloned
+	fieldPolicy helping.FieldPolicy
+	// customFieldPolicy is true if fieldPolicy has policies beyond the default rule
+	customFieldPolicy bool
+	// preserveAliasing makes shared maps and slices cloned once and shared in the clone
+	preserveAliasing bool
+	// cloneFuncs maps types to their registered clone functions
+	cloneFuncs map[reflect.Type]CloneFunc
+	// hooks caches the CloneFunc of each cloned type, nil if the type has none
+	hooks *sync.Map
+	// referencePolicy decides which struct fields are references, nil if none
+	referencePolicy helping.ReferencePolicy
+	// redactPolicy matches the sensitive struct fields to redact, nil if none
+	redactPolicy helping.FieldPolicy
+	// redactMarker replaces the redacted strings
+	redactMarker string
+	// parallelThreshold is the minimal length of slices and maps cloned in parallel, 0 if disabled
+	parallelThreshold int
+	// parallelSlots bounds the number of goroutines cloning in parallel
+	parallelSlots chan struct{}
+	// limits bounds the values cloned, nil if unlimited
+	limits *Limits
+	// introspector validates the CloneMask paths against its node tree, nil if not set
+	introspector ifs.IIntrospector
+}
+
+// DeepCloner is implemented by types that clone themselves.
+// The Cloner calls DeepClone instead of cloning the value field by field, unless field,
+// reference or redact policies are set, which DeepCloner implementations ignore.
+// DeepClone must return a value of the implementing type and must not call
+// the Cloner on the same value, as that would recurse.
+type DeepCloner interface {
+	DeepClone() interface{}
+}
+
+// CloneFunc clones a value of the type it is registered for.
+// It returns the clone, a value of the same type.
+type CloneFunc func(any interface{}) interface{}
+
+// deepClonerType is the reflect.Type of the DeepCloner interface.
+var deepClonerType = reflect.TypeOf((*DeepCloner)(nil)).Elem()
+
+// cloneKey identifies an already cloned reference by its address and type.
+// Slices also carry their length, as sub-slices share the address of their backing array.
+type cloneKey struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
 }
 
 // NewCloner creates and initializes a new Cloner instance.
 // The returned Cloner is ready to clone any Go data structure.
 func NewCloner() *Cloner {
 	cloner := &Cloner{}
+	cloner.fieldPolicy = helping.DefaultFieldPolicy
+	cloner.cloneFuncs = make(map[reflect.Type]CloneFunc)
+	cloner.hooks = &sync.Map{}
 	cloner.initCloners()
 	return cloner
 }
 
+// SetFieldPolicy sets the policies deciding which struct fields are left zero in the clone.
+// The default rule (see SkipFieldByName) is always applied in addition to the given policies.
+func (this *Cloner) SetFieldPolicy(policies ...helping.FieldPolicy) {
+	this.fieldPolicy = helping.NewFieldPolicy(policies...)
+	this.customFieldPolicy = len(policies) > 0
+}
+
+// SetPreserveAliasing sets whether maps and slices referenced from several places are
+// cloned once, so the clone keeps the same sharing graph as the original.
+// Pointers are always cloned once per original address.
+func (this *Cloner) SetPreserveAliasing(preserve bool) {
+	this.preserveAliasing = preserve
+}
+
+// SetReferencePolicy sets the policy deciding which struct fields reference other objects.
+// Reference fields are copied as is, or as new instances holding only the key fields
+// of the referenced objects, instead of being deep cloned.
+func (this *Cloner) SetReferencePolicy(policy helping.ReferencePolicy) {
+	this.referencePolicy = policy
+}
+
+// SetRedactPolicy makes the Cloner redact the struct fields matched by any of the policies,
+// e.g. helping.NewNamePolicy("*Password*") or an Introspector's SensitiveDecoratorPolicy.
+// Redacted strings are replaced by marker, including the strings inside slices, arrays,
+// maps and pointers, which keep their shape and keys. All other redacted values are zero.
+// Calling it without policies turns redaction off.
+func (this *Cloner) SetRedactPolicy(marker string, policies ...helping.FieldPolicy) {
+	this.redactMarker = marker
+	this.redactPolicy = nil
+	if len(policies) > 0 {
+		this.redactPolicy = compositeMatch(policies)
+	}
+}
+
+// compositeMatch matches a field if any of its policies matches it.
+type compositeMatch []helping.FieldPolicy
+
+func (this compositeMatch) SkipField(structType reflect.Type, field reflect.StructField) bool {
+	for _, policy := range this {
+		if policy != nil && policy.SkipField(structType, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// RegisterCloneFunc registers a clone function for the given type, overriding both the
+// kind-based cloners and the DeepCloner interface for values of that type, wherever they
+// are nested. Register pointer types (e.g. *sync.Mutex) to hook pointer fields.
+// Registration should be done before the Cloner is used concurrently.
+func (this *Cloner) RegisterCloneFunc(typ reflect.Type, cloneFunc CloneFunc) {
+	this.cloneFuncs[typ] = cloneFunc
+	this.hooks = &sync.Map{}
+}
+
 // initCloners initializes the cloning function registry with handlers for all supported Go types.
 // Each handler is responsible for cloning values of a specific reflect.Kind.
 func (this *Cloner) initCloners() {
-	this.cloners = make(map[reflect.Kind]func(reflect.Value, string, map[string]reflect.Value) reflect.Value)
+	this.cloners = make(map[reflect.Kind]func(reflect.Value, string, *cloneLoop) reflect.Value)
 	this.cloners[reflect.Int] = this.intCloner
-	this.cloners[reflect.Int8] = this.int8Cloner
-	this.cloners[reflect.Int16] = this.int16Cloner
-	this.cloners[reflect.Int32] = this.int32Cloner
-	this.cloners[reflect.Int64] = this.int64Cloner
+	this.cloners[reflect.Int8] = this.intCloner
+	this.cloners[reflect.Int16] = this.intCloner
+	this.cloners[reflect.Int32] = this.intCloner
+	this.cloners[reflect.Int64] = this.intCloner
 	this.cloners[reflect.Uint] = this.uintCloner
-	this.cloners[reflect.Uint8] = this.uint8Cloner
-	this.cloners[reflect.Uint16] = this.uint16Cloner
-	this.cloners[reflect.Uint32] = this.uint32Cloner
-	this.cloners[reflect.Uint64] = this.uint64Cloner
-	this.cloners[reflect.Float32] = this.float32Cloner
-	this.cloners[reflect.Float64] = this.float64Cloner
-	this.cloners[reflect.Complex64] = this.complex64Cloner
-	this.cloners[reflect.Complex128] = this.complex128Cloner
+	this.cloners[reflect.Uint8] = this.uintCloner
+	this.cloners[reflect.Uint16] = this.uintCloner
+	this.cloners[reflect.Uint32] = this.uintCloner
+	this.cloners[reflect.Uint64] = this.uintCloner
+	this.cloners[reflect.Uintptr] = this.uintCloner
+	this.cloners[reflect.Float32] = this.floatCloner
+	this.cloners[reflect.Float64] = this.floatCloner
+	this.cloners[reflect.Complex64] = this.complexCloner
+	this.cloners[reflect.Complex128] = this.complexCloner
 	this.cloners[reflect.Bool] = this.boolCloner
 	this.cloners[reflect.String] = this.stringCloner
 	this.cloners[reflect.Array] = this.arrayCloner
@@ -77,14 +192,16 @@ func (this *Cloner) initCloners() {
 // Clone performs a deep clone of the provided value and returns the cloned copy.
 // It handles all Go types including primitives, slices, maps, structs, and pointers.
 // Circular references are detected and handled to prevent infinite recursion.
-// Fields matching certain patterns (DoNotCompare, DoNotCopy, XXX prefix, private) are skipped.
+// Fields matching the field policy (by default DoNotCompare, DoNotCopy, XXX prefix, private) are skipped.
 // Returns nil if the input is nil or if the cloned value is invalid.
+// Panics with a *LimitError if the value exceeds the limits set by SetLimits.
 func (this *Cloner) Clone(any interface{}) interface{} {
 	if any == nil {
 		return nil
 	}
 	value := reflect.ValueOf(any)
-	stopLoop := make(map[string]reflect.Value)
+	stopLoop := newCloneLoop(this.parallelThreshold > 0 && this.limits == nil)
+	this.limitLoop(stopLoop, value)
 	valueClone := this.clone(value, "", stopLoop)
 	if !valueClone.IsValid() {
 		return nil
@@ -93,11 +210,18 @@ func (this *Cloner) Clone(any interface{}) interface{} {
 }
 
 // clone is the internal recursive cloning function that dispatches to type-specific cloners.
-// The stopLoop map tracks pointer addresses to detect and handle circular references.
-func (this *Cloner) clone(value reflect.Value, fieldName string, stopLoop map[string]reflect.Value) reflect.Value {
+// The stopLoop map tracks cloned references by address and type to detect and handle circular references.
+func (this *Cloner) clone(value reflect.Value, fieldName string, stopLoop *cloneLoop) reflect.Value {
 	if !value.IsValid() {
 		return value
 	}
+	if stopLoop.counter != nil {
+		defer stopLoop.checkLimits(value)()
+	}
+	hooked, ok := this.cloneHook(value, stopLoop)
+	if ok {
+		return hooked
+	}
 	kind := value.Kind()
 	cloner := this.cloners[kind]
 	if cloner == nil {
@@ -106,168 +230,387 @@ func (this *Cloner) clone(value reflect.Value, fieldName string, stopLoop map[st
 	return cloner(value, fieldName, stopLoop)
 }
 
-// sliceCloner creates a deep copy of a slice value, recursively cloning each element.
+// cloneHook clones the value using its registered CloneFunc or its DeepCloner implementation.
+// Returns false if the value's type has neither, or only the latter while policies are set. Hooked pointers are tracked in stopLoop
+// so shared references are cloned once.
+func (this *Cloner) cloneHook(value reflect.Value, stopLoop *cloneLoop) (reflect.Value, bool) {
+	typ := value.Type()
+	kind := value.Kind()
+	if kind == reflect.Interface || !value.CanInterface() {
+		return value, false
+	}
+	cloneFunc := this.hook(typ)
+	if cloneFunc == nil {
+		return value, false
+	}
+	if this.hasPolicies() || this.limits != nil {
+		// only registered clone funcs override the policies and limits
+		_, registered := this.cloneFuncs[typ]
+		if !registered {
+			return value, false
+		}
+	}
+	switch kind {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		if value.IsNil() {
+			return value, true
+		}
+	}
+	key := cloneKey{typ: typ}
+	if kind == reflect.Ptr {
+		key.ptr = value.Pointer()
+		exist, ok := stopLoop.load(key)
+		if ok {
+			return exist, true
+		}
+	}
+	result := reflect.ValueOf(cloneFunc(value.Interface()))
+	if !result.IsValid() {
+		result = reflect.Zero(typ)
+	} else if kind == reflect.Ptr && result.Type() == typ.Elem() {
+		// a value receiver DeepClone called through a pointer returns the value
+		newPtr := reflect.New(typ.Elem())
+		newPtr.Elem().Set(result)
+		result = newPtr
+	} else if result.Type() != typ && result.Type().ConvertibleTo(typ) {
+		result = result.Convert(typ)
+	}
+	if kind == reflect.Ptr {
+		result, _ = stopLoop.loadOrStore(key, result)
+	}
+	return result, true
+}
+
+// hook returns the registered CloneFunc of a type, or its DeepCloner implementation,
+// nil if it has neither. The decision is cached per type.
+func (this *Cloner) hook(typ reflect.Type) CloneFunc {
+	cached, ok := this.hooks.Load(typ)
+	if ok {
+		return cached.(CloneFunc)
+	}
+	cloneFunc, ok := this.cloneFuncs[typ]
+	if !ok && typ.Implements(deepClonerType) {
+		cloneFunc = deepClone
+	}
+	this.hooks.Store(typ, cloneFunc)
+	return cloneFunc
+}
+
+// hasPolicies returns true if field, reference or redact policies are set.
+func (this *Cloner) hasPolicies() bool {
+	return this.customFieldPolicy || this.referencePolicy != nil || this.redactPolicy != nil
+}
+
+// deepClone is the CloneFunc of types implementing DeepCloner.
+func deepClone(any interface{}) interface{} {
+	return any.(DeepCloner).DeepClone()
+}
+
+// sliceCloner creates a deep copy of a slice value of the same (possibly named) slice type,
+// recursively cloning each element.
 // Returns the original value if the slice is nil.
-func (this *Cloner) sliceCloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
+// When aliasing is preserved, a slice already cloned is returned from stopLoop.
+func (this *Cloner) sliceCloner(value reflect.Value, name string, stopLoop *cloneLoop) reflect.Value {
 	if value.IsNil() {
 		return value
 	}
-	newSlice := reflect.MakeSlice(reflect.SliceOf(value.Type().Elem()), value.Len(), value.Len())
-	for i := 0; i < value.Len(); i++ {
-		elem := value.Index(i)
-		elemClone := this.clone(elem, name, stopLoop)
-		newSlice.Index(i).Set(elemClone)
+	newSlice := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+	if this.preserveAliasing && value.Len() > 0 {
+		key := cloneKey{ptr: value.Pointer(), typ: value.Type(), len: value.Len()}
+		exist, loaded := stopLoop.loadOrStore(key, newSlice)
+		if loaded {
+			return exist
+		}
 	}
+	this.forRange(stopLoop, value.Len(), func(from, to int) {
+		for i := from; i < to; i++ {
+			elem := value.Index(i)
+			stopLoop.pushIndex(i)
+			elemClone := this.clone(elem, name, stopLoop)
+			stopLoop.pop()
+			newSlice.Index(i).Set(elemClone)
+		}
+	})
 	return newSlice
 }
 
 // ptrCloner creates a deep copy of a pointer value.
 // It tracks pointer addresses in stopLoop to detect and handle circular references.
 // If a pointer has already been cloned, returns the existing clone to break cycles.
-func (this *Cloner) ptrCloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
+func (this *Cloner) ptrCloner(value reflect.Value, name string, stopLoop *cloneLoop) reflect.Value {
 	if value.IsNil() {
 		return value
 	}
 
-	p := strconv.Itoa(int(value.Pointer()))
-	exist, ok := stopLoop[p]
+	key := cloneKey{ptr: value.Pointer(), typ: value.Type()}
+	exist, ok := stopLoop.load(key)
 	if ok {
 		return exist
 	}
 
 	newPtr := reflect.New(value.Elem().Type())
-	stopLoop[p] = newPtr
+	clone := newPtr
+	if clone.Type() != value.Type() {
+		// named pointer types, e.g. type Ref *Label
+		clone = newPtr.Convert(value.Type())
+	}
+	exist, loaded := stopLoop.loadOrStore(key, clone)
+	if loaded {
+		return exist
+	}
 
 	newPtr.Elem().Set(this.clone(value.Elem(), name, stopLoop))
 
-	return newPtr
+	return clone
 }
 
 // structCloner creates a deep copy of a struct value.
-// It iterates through all fields, skipping those matching SkipFieldByName criteria,
+// It iterates through all fields, skipping those matching the field policy,
 // and recursively clones each eligible field.
-func (this *Cloner) structCloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
+func (this *Cloner) structCloner(value reflect.Value, name string, stopLoop *cloneLoop) reflect.Value {
 	cloneStruct := reflect.New(value.Type()).Elem()
 	structType := value.Type()
 	for i := 0; i < structType.NumField(); i++ {
 		fieldValue := value.Field(i)
-		fieldName := structType.Field(i).Name
-		if SkipFieldByName(fieldName) {
+		field := structType.Field(i)
+		if this.fieldPolicy.SkipField(structType, field) {
 			continue
 		}
-		cloned := this.clone(fieldValue, structType.Field(i).Name, stopLoop)
-		if cloned.Kind() == reflect.Int32 {
-			cloneStruct.Field(i).SetInt(cloned.Int())
-		} else {
-			cloneStruct.Field(i).Set(cloned)
-		}
+		stopLoop.pushField(field.Name)
+		cloneStruct.Field(i).Set(this.cloneField(structType, field, fieldValue, stopLoop))
+		stopLoop.pop()
 	}
 	return cloneStruct
 }
 
+// cloneField clones the value of a struct field, honouring the redact and reference policies.
+func (this *Cloner) cloneField(structType reflect.Type, field reflect.StructField, value reflect.Value, stopLoop *cloneLoop) reflect.Value {
+	if this.isRedacted(structType, field) {
+		return this.redact(value)
+	}
+	if this.referencePolicy != nil {
+		isReference, keyFields := this.referencePolicy.Reference(structType, field)
+		if isReference {
+			if len(keyFields) == 0 {
+				return value
+			}
+			return this.keyClone(value, keyFields, stopLoop)
+		}
+	}
+	return this.clone(value, field.Name, stopLoop)
+}
+
+// isRedacted returns true if the field is matched by the redact policy.
+func (this *Cloner) isRedacted(structType reflect.Type, field reflect.StructField) bool {
+	return this.redactPolicy != nil && this.redactPolicy.SkipField(structType, field)
+}
+
+// redact returns the redacted copy of a sensitive value.
+// Strings become the redact marker, containers keep their shape with redacted elements.
+func (this *Cloner) redact(value reflect.Value) reflect.Value {
+	redacted := reflect.New(value.Type()).Elem()
+	switch value.Kind() {
+	case reflect.String:
+		redacted.SetString(this.redactMarker)
+	case reflect.Slice:
+		if value.IsNil() {
+			return redacted
+		}
+		redacted = reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			redacted.Index(i).Set(this.redact(value.Index(i)))
+		}
+	case reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			redacted.Index(i).Set(this.redact(value.Index(i)))
+		}
+	case reflect.Map:
+		if value.IsNil() {
+			return redacted
+		}
+		redacted = reflect.MakeMapWithSize(value.Type(), value.Len())
+		for _, key := range value.MapKeys() {
+			redacted.SetMapIndex(key, this.redact(value.MapIndex(key)))
+		}
+	case reflect.Ptr:
+		if value.IsNil() {
+			return redacted
+		}
+		switch value.Elem().Kind() {
+		case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+			newPtr := reflect.New(value.Elem().Type())
+			newPtr.Elem().Set(this.redact(value.Elem()))
+			redacted.Set(newPtr.Convert(value.Type()))
+		}
+	case reflect.Interface:
+		if !value.IsNil() && value.Elem().Kind() == reflect.String {
+			redacted.Set(this.redact(value.Elem()))
+		}
+	}
+	return redacted
+}
+
+// keyClone clones referenced objects with only their key fields populated.
+// Pointers, slices, arrays and maps of referenced objects keep their shape.
+func (this *Cloner) keyClone(value reflect.Value, keyFields []string, stopLoop *cloneLoop) reflect.Value {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return value
+		}
+		newPtr := reflect.New(value.Elem().Type())
+		setCloned(newPtr.Elem(), this.keyClone(value.Elem(), keyFields, stopLoop))
+		return newPtr
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		return this.keyClone(value.Elem(), keyFields, stopLoop)
+	case reflect.Struct:
+		keyStruct := reflect.New(value.Type()).Elem()
+		for _, keyField := range keyFields {
+			fieldValue := value.FieldByName(keyField)
+			if !fieldValue.IsValid() {
+				continue
+			}
+			setCloned(keyStruct.FieldByName(keyField), this.clone(fieldValue, keyField, stopLoop))
+		}
+		return keyStruct
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		newSlice := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			setCloned(newSlice.Index(i), this.keyClone(value.Index(i), keyFields, stopLoop))
+		}
+		return newSlice
+	case reflect.Array:
+		newArray := reflect.New(value.Type()).Elem()
+		for i := 0; i < value.Len(); i++ {
+			setCloned(newArray.Index(i), this.keyClone(value.Index(i), keyFields, stopLoop))
+		}
+		return newArray
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		mapClone := reflect.MakeMapWithSize(value.Type(), value.Len())
+		for _, key := range value.MapKeys() {
+			elemClone := reflect.New(value.Type().Elem()).Elem()
+			setCloned(elemClone, this.keyClone(value.MapIndex(key), keyFields, stopLoop))
+			mapClone.SetMapIndex(key, elemClone)
+		}
+		return mapClone
+	}
+	return value
+}
+
+// setCloned sets a cloned value into target, converting primitives back to named types.
+func setCloned(target, cloned reflect.Value) {
+	if !cloned.IsValid() {
+		return
+	}
+	if cloned.Type() != target.Type() && cloned.Type().ConvertibleTo(target.Type()) &&
+		cloned.Kind() == target.Kind() {
+		cloned = cloned.Convert(target.Type())
+	}
+	target.Set(cloned)
+}
+
 // mapCloner creates a deep copy of a map value, recursively cloning each key-value pair.
 // Returns the original value if the map is nil.
-func (this *Cloner) mapCloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
+// When aliasing is preserved, a map already cloned is returned from stopLoop.
+func (this *Cloner) mapCloner(value reflect.Value, name string, stopLoop *cloneLoop) reflect.Value {
 	if value.IsNil() {
 		return value
 	}
 	mapKeys := value.MapKeys()
 	mapClone := reflect.MakeMapWithSize(value.Type(), len(mapKeys))
+	if this.preserveAliasing {
+		key := cloneKey{ptr: value.Pointer(), typ: value.Type()}
+		exist, loaded := stopLoop.loadOrStore(key, mapClone)
+		if loaded {
+			return exist
+		}
+	}
+	if this.isParallel(stopLoop, len(mapKeys)) {
+		// values are cloned in parallel, the map itself is only written by this goroutine
+		elemClones := make([]reflect.Value, len(mapKeys))
+		this.forRange(stopLoop, len(mapKeys), func(from, to int) {
+			for i := from; i < to; i++ {
+				elemClones[i] = this.clone(value.MapIndex(mapKeys[i]), name, stopLoop)
+			}
+		})
+		for i, key := range mapKeys {
+			mapClone.SetMapIndex(key, elemClones[i])
+		}
+		return mapClone
+	}
 	for _, key := range mapKeys {
 		mapElem := value.MapIndex(key)
+		stopLoop.pushMapKey(key)
 		mapElemClone := this.clone(mapElem, name, stopLoop)
+		stopLoop.pop()
 		mapClone.SetMapIndex(key, mapElemClone)
 	}
 	return mapClone
 }
 
-func (this *Cloner) intCloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
-	i := value.Int()
-	return reflect.ValueOf(int(i))
-}
-
-func (this *Cloner) uintCloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
-	i := value.Uint()
-	return reflect.ValueOf(uint(i))
-}
-
-func (this *Cloner) uint32Cloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
-	i := value.Uint()
-	return reflect.ValueOf(uint32(i))
-}
-
-func (this *Cloner) uint64Cloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
-	i := value.Uint()
-	return reflect.ValueOf(uint64(i))
-}
-
-func (this *Cloner) float32Cloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
-	i := value.Float()
-	return reflect.ValueOf(float32(i))
-}
-
-func (this *Cloner) float64Cloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
-	i := value.Float()
-	return reflect.ValueOf(float64(i))
-}
-
-func (this *Cloner) boolCloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
-	b := value.Bool()
-	return reflect.ValueOf(b)
-}
-
-func (this *Cloner) int32Cloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
-	i := value.Int()
-	return reflect.ValueOf(int32(i))
-}
-
-func (this *Cloner) int64Cloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
-	i := value.Int()
-	return reflect.ValueOf(int64(i))
-}
+// Primitive cloners return a new value of the original type, so named types
+// (e.g. enums declared as `type Severity int32`) keep their type in the clone.
 
-func (this *Cloner) stringCloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
-	s := value.String()
-	return reflect.ValueOf(s)
+// intCloner clones a signed integer value of any size.
+func (this *Cloner) intCloner(value reflect.Value, name string, stopLoop *cloneLoop) reflect.Value {
+	clone := reflect.New(value.Type()).Elem()
+	clone.SetInt(value.Int())
+	return clone
 }
 
-func (this *Cloner) int8Cloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
-	i := value.Int()
-	return reflect.ValueOf(int8(i))
+// uintCloner clones an unsigned integer value of any size.
+func (this *Cloner) uintCloner(value reflect.Value, name string, stopLoop *cloneLoop) reflect.Value {
+	clone := reflect.New(value.Type()).Elem()
+	clone.SetUint(value.Uint())
+	return clone
 }
 
-func (this *Cloner) int16Cloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
-	i := value.Int()
-	return reflect.ValueOf(int16(i))
+// floatCloner clones a float32 or float64 value.
+func (this *Cloner) floatCloner(value reflect.Value, name string, stopLoop *cloneLoop) reflect.Value {
+	clone := reflect.New(value.Type()).Elem()
+	clone.SetFloat(value.Float())
+	return clone
 }
 
-func (this *Cloner) uint8Cloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
-	i := value.Uint()
-	return reflect.ValueOf(uint8(i))
+// complexCloner clones a complex64 or complex128 value.
+func (this *Cloner) complexCloner(value reflect.Value, name string, stopLoop *cloneLoop) reflect.Value {
+	clone := reflect.New(value.Type()).Elem()
+	clone.SetComplex(value.Complex())
+	return clone
 }
 
-func (this *Cloner) uint16Cloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
-	i := value.Uint()
-	return reflect.ValueOf(uint16(i))
+// boolCloner clones a bool value.
+func (this *Cloner) boolCloner(value reflect.Value, name string, stopLoop *cloneLoop) reflect.Value {
+	clone := reflect.New(value.Type()).Elem()
+	clone.SetBool(value.Bool())
+	return clone
 }
 
-func (this *Cloner) complex64Cloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
-	c := value.Complex()
-	return reflect.ValueOf(complex64(c))
-}
-
-func (this *Cloner) complex128Cloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
-	c := value.Complex()
-	return reflect.ValueOf(complex128(c))
+// stringCloner clones a string value.
+func (this *Cloner) stringCloner(value reflect.Value, name string, stopLoop *cloneLoop) reflect.Value {
+	clone := reflect.New(value.Type()).Elem()
+	clone.SetString(value.String())
+	return clone
 }
 
 // arrayCloner creates a deep copy of an array value, recursively cloning each element.
-func (this *Cloner) arrayCloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
+func (this *Cloner) arrayCloner(value reflect.Value, name string, stopLoop *cloneLoop) reflect.Value {
 	arrayType := value.Type()
 	newArray := reflect.New(arrayType).Elem()
 	for i := 0; i < value.Len(); i++ {
 		elem := value.Index(i)
+		stopLoop.pushIndex(i)
 		elemClone := this.clone(elem, name, stopLoop)
+		stopLoop.pop()
 		newArray.Index(i).Set(elemClone)
 	}
 	return newArray
@@ -275,7 +618,7 @@ func (this *Cloner) arrayCloner(value reflect.Value, name string, stopLoop map[s
 
 // interfaceCloner clones the concrete value inside an interface.
 // Returns the original value if the interface is nil.
-func (this *Cloner) interfaceCloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
+func (this *Cloner) interfaceCloner(value reflect.Value, name string, stopLoop *cloneLoop) reflect.Value {
 	if value.IsNil() {
 		return value
 	}
@@ -287,7 +630,7 @@ func (this *Cloner) interfaceCloner(value reflect.Value, name string, stopLoop m
 	return clonedConcrete
 }
 
-func (this *Cloner) chanCloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
+func (this *Cloner) chanCloner(value reflect.Value, name string, stopLoop *cloneLoop) reflect.Value {
 	if value.IsNil() {
 		return value
 	}
@@ -298,7 +641,7 @@ func (this *Cloner) chanCloner(value reflect.Value, name string, stopLoop map[st
 	return newChan
 }
 
-func (this *Cloner) funcCloner(value reflect.Value, name string, stopLoop map[string]reflect.Value) reflect.Value {
+func (this *Cloner) funcCloner(value reflect.Value, name string, stopLoop *cloneLoop) reflect.Value {
 	if value.IsNil() {
 		return value
 	}
@@ -312,18 +655,8 @@ func (this *Cloner) funcCloner(value reflect.Value, name string, stopLoop map[st
 //   - Field name is "DoNotCopy"
 //   - Field name starts with "XXX" (protobuf internal fields)
 //   - Field name starts with a lowercase letter (unexported/private fields)
+//
+// It is the same rule as helping.IgnoreName, which backs helping.DefaultFieldPolicy.
 func SkipFieldByName(fieldName string) bool {
-	if fieldName == "DoNotCompare" {
-		return true
-	}
-	if fieldName == "DoNotCopy" {
-		return true
-	}
-	if len(fieldName) > 3 && fieldName[0:3] == "XXX" {
-		return true
-	}
-	if fieldName[0:1] == strings.ToLower(fieldName[0:1]) {
-		return true
-	}
-	return false
+	return helping.IgnoreName(fieldName)
 }
@@ -16,28 +16,72 @@ package cloning
 import (
 	"bytes"
 	"reflect"
+
+	"github.com/saichler/l8reflect/go/reflect/helping"
 )
 
 // DeepEqual provides deep equality comparison for Go data structures.
 // It compares values by recursively examining their contents rather than
-// just comparing memory addresses. It supports all Go primitive types,
-// slices, maps, structs, and pointers.
+// just comparing memory addresses. Every reflect.Kind is supported:
+//   - primitives and complex numbers by value
+//   - pointers, structs, slices, arrays and maps by their contents
+//   - interfaces by their dynamic type and value
+//   - channels and unsafe pointers by identity
+//   - funcs by identity (the same function, or both nil)
 type DeepEqual struct {
 	// comparators maps each reflect.Kind to its corresponding comparison function
-	comparators map[reflect.Kind]func(reflect.Value, reflect.Value) bool
+	comparators map[reflect.Kind]func(reflect.Value, reflect.Value, *equalState) bool
+	// fieldPolicy decides which struct fields are not compared
+	fieldPolicy helping.FieldPolicy
+	// customFieldPolicy is true if fieldPolicy has policies beyond the default rule
+	customFieldPolicy bool
+	// options relaxes the equality semantics, nil for exact equality
+	options *EqualOptions
+	// ignore maps the root type names of the ignored paths to their masks
+	ignore map[string]*cloneMask
+	// orderPolicy decides which slice fields are compared regardless of their elements order
+	orderPolicy helping.SliceOrderPolicy
+	// limits bounds the values compared, nil if unlimited
+	limits *Limits
+}
+
+// DeepEqualer is implemented by types that compare themselves, such as the types
+// generated by cmd/l8reflect-gen. DeepEqual calls it instead of comparing field by field
+// when both sides are non nil values of the implementing type, unless field policies,
+// options or a slice order policy are set, which DeepEqualer implementations ignore.
+type DeepEqualer interface {
+	DeepEqual(other interface{}) bool
 }
 
+// deepEqualerType is the reflect.Type of the DeepEqualer interface.
+var deepEqualerType = reflect.TypeOf((*DeepEqualer)(nil)).Elem()
+
 // NewDeepEqual creates and initializes a new DeepEqual instance.
 // The returned DeepEqual is ready to compare any Go data structures.
 func NewDeepEqual() *DeepEqual {
 	de := &DeepEqual{}
+	de.fieldPolicy = helping.DefaultFieldPolicy
 	de.initCloners()
 	return de
 }
 
+// SetFieldPolicy sets the policies deciding which struct fields are ignored when comparing.
+// The default rule (see SkipFieldByName) is always applied in addition to the given policies.
+func (this *DeepEqual) SetFieldPolicy(policies ...helping.FieldPolicy) {
+	this.fieldPolicy = helping.NewFieldPolicy(policies...)
+	this.customFieldPolicy = len(policies) > 0
+}
+
+// SetSliceOrderPolicy sets the policy deciding which slice fields are compared regardless
+// of the order of their elements, by key or as multisets, e.g. the Introspector's
+// SliceOrderDecoratorPolicy. Nil restores the ordered comparison of all slices.
+func (this *DeepEqual) SetSliceOrderPolicy(policy helping.SliceOrderPolicy) {
+	this.orderPolicy = policy
+}
+
 // initCloners initializes the comparison function registry with handlers for all supported Go types.
 func (this *DeepEqual) initCloners() {
-	this.comparators = make(map[reflect.Kind]func(reflect.Value, reflect.Value) bool)
+	this.comparators = make(map[reflect.Kind]func(reflect.Value, reflect.Value, *equalState) bool)
 	this.comparators[reflect.Int] = this.intComp
 	this.comparators[reflect.Int8] = this.intComp
 	this.comparators[reflect.Int16] = this.intComp
@@ -49,6 +93,7 @@ func (this *DeepEqual) initCloners() {
 	this.comparators[reflect.Uint16] = this.uintComp
 	this.comparators[reflect.Uint32] = this.uintComp
 	this.comparators[reflect.Uint64] = this.uintComp
+	this.comparators[reflect.Uintptr] = this.uintComp
 
 	this.comparators[reflect.String] = this.stringComp
 
@@ -57,27 +102,67 @@ func (this *DeepEqual) initCloners() {
 	this.comparators[reflect.Float32] = this.floatComp
 	this.comparators[reflect.Float64] = this.floatComp
 
+	this.comparators[reflect.Complex64] = this.complexComp
+	this.comparators[reflect.Complex128] = this.complexComp
+
 	this.comparators[reflect.Ptr] = this.ptrComp
 
 	this.comparators[reflect.Struct] = this.structComp
 
 	this.comparators[reflect.Slice] = this.sliceComp
 
+	this.comparators[reflect.Array] = this.arrayComp
+
 	this.comparators[reflect.Map] = this.mapComp
 
+	this.comparators[reflect.Interface] = this.interfaceComp
+
+	this.comparators[reflect.Chan] = this.identityComp
+	this.comparators[reflect.Func] = this.identityComp
+	this.comparators[reflect.UnsafePointer] = this.identityComp
 }
 
 // Equal compares two values for deep equality.
 // Returns true if both values have identical contents, false otherwise.
 // Handles nil values, different kinds, and recursively compares composite types.
+// Panics with a *LimitError if the values exceed the limits set by SetLimits.
 func (this *DeepEqual) Equal(aSide, zSide interface{}) bool {
 	aSideValue := reflect.ValueOf(aSide)
 	zSideValue := reflect.ValueOf(zSide)
-	return this.equal(aSideValue, zSideValue)
+	return this.equal(aSideValue, zSideValue, this.newEqualState(aSideValue, zSideValue, nil))
+}
+
+// EqualWithError compares two values for deep equality like Equal, but returns an error
+// instead of panicking, e.g. when a DeepEqualer implementation panics or when values
+// of unrelated types with the same kind and name cannot be compared.
+// A *LimitError is returned as is.
+func (this *DeepEqual) EqualWithError(aSide, zSide interface{}) (eq bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			eq = false
+			err = recoverError(r, "DeepEqual")
+		}
+	}()
+	return this.Equal(aSide, zSide), nil
 }
 
 // equal is the internal recursive comparison function that dispatches to type-specific comparators.
-func (this *DeepEqual) equal(aSideValue, zSideValue reflect.Value) bool {
+// When the differences are reported, a mismatch not reported by a nested comparison
+// is reported at the current property path.
+func (this *DeepEqual) equal(aSideValue, zSideValue reflect.Value, state *equalState) bool {
+	if state.report == nil {
+		return this.compare(aSideValue, zSideValue, state)
+	}
+	reported := len(state.report.differences)
+	eq := this.compare(aSideValue, zSideValue, state)
+	if !eq && len(state.report.differences) == reported {
+		state.addDifference(aSideValue, zSideValue)
+	}
+	return eq
+}
+
+// compare checks the validity and kinds of the values and dispatches to the comparator of their kind.
+func (this *DeepEqual) compare(aSideValue, zSideValue reflect.Value, state *equalState) bool {
 	if aSideValue.IsValid() && !zSideValue.IsValid() {
 		return false
 	}
@@ -90,45 +175,107 @@ func (this *DeepEqual) equal(aSideValue, zSideValue reflect.Value) bool {
 	if aSideValue.Kind() != zSideValue.Kind() {
 		return false
 	}
+	if state.counter != nil {
+		defer state.checkLimits(aSideValue, zSideValue)()
+	}
+
+	if this.isDeepEqualer(aSideValue, zSideValue) {
+		eq := aSideValue.Interface().(DeepEqualer).DeepEqual(zSideValue.Interface())
+		// when reporting, a mismatch is walked reflectively to find the differing properties
+		if eq || state.report == nil {
+			return eq
+		}
+	}
 
 	kind := aSideValue.Kind()
 	comparator := this.comparators[kind]
 	if comparator == nil {
 		panic("No comparator for kind:" + kind.String() + ", please add it!")
 	}
-	return comparator(aSideValue, zSideValue)
+	return comparator(aSideValue, zSideValue, state)
+}
+
+// isDeepEqualer returns true if both values are non nil values of the same type
+// implementing DeepEqualer, and the DeepEqual has its default configuration and no limits.
+func (this *DeepEqual) isDeepEqualer(aSideValue, zSideValue reflect.Value) bool {
+	if this.customFieldPolicy || this.options != nil || this.orderPolicy != nil || this.limits != nil {
+		return false
+	}
+	typ := aSideValue.Type()
+	if typ != zSideValue.Type() || typ.Kind() == reflect.Interface || !aSideValue.CanInterface() {
+		return false
+	}
+	if !typ.Implements(deepEqualerType) {
+		return false
+	}
+	if typ.Kind() == reflect.Ptr && (aSideValue.IsNil() || zSideValue.IsNil()) {
+		return false
+	}
+	return true
 }
 
 // Type-specific comparator functions for primitive and composite types
 
 // intComp compares two integer values (int, int32, int64).
-func (this *DeepEqual) intComp(aSideValue, zSideValue reflect.Value) bool {
+func (this *DeepEqual) intComp(aSideValue, zSideValue reflect.Value, state *equalState) bool {
 	return aSideValue.Int() == zSideValue.Int()
 }
 
 // uintComp compares two unsigned integer values (uint, uint32, uint64).
-func (this *DeepEqual) uintComp(aSideValue, zSideValue reflect.Value) bool {
+func (this *DeepEqual) uintComp(aSideValue, zSideValue reflect.Value, state *equalState) bool {
 	return aSideValue.Uint() == zSideValue.Uint()
 }
 
 // stringComp compares two string values.
-func (this *DeepEqual) stringComp(aSideValue, zSideValue reflect.Value) bool {
+func (this *DeepEqual) stringComp(aSideValue, zSideValue reflect.Value, state *equalState) bool {
 	return aSideValue.String() == zSideValue.String()
 }
 
 // boolComp compares two boolean values.
-func (this *DeepEqual) boolComp(aSideValue, zSideValue reflect.Value) bool {
+func (this *DeepEqual) boolComp(aSideValue, zSideValue reflect.Value, state *equalState) bool {
 	return aSideValue.Bool() == zSideValue.Bool()
 }
 
 // floatComp compares two floating-point values (float32, float64).
-func (this *DeepEqual) floatComp(aSideValue, zSideValue reflect.Value) bool {
-	return aSideValue.Float() == zSideValue.Float()
+// The tolerance and NaN options apply.
+func (this *DeepEqual) floatComp(aSideValue, zSideValue reflect.Value, state *equalState) bool {
+	return this.FloatEqual(aSideValue.Float(), zSideValue.Float())
+}
+
+// complexComp compares two complex values (complex64, complex128).
+// The float options apply to the real and imaginary parts.
+func (this *DeepEqual) complexComp(aSideValue, zSideValue reflect.Value, state *equalState) bool {
+	aSide := aSideValue.Complex()
+	zSide := zSideValue.Complex()
+	return this.FloatEqual(real(aSide), real(zSide)) && this.FloatEqual(imag(aSide), imag(zSide))
+}
+
+// interfaceComp compares two interface values by their dynamic type and value.
+// Two nil interfaces are equal, values of different dynamic types are not.
+func (this *DeepEqual) interfaceComp(aSideValue, zSideValue reflect.Value, state *equalState) bool {
+	if aSideValue.IsNil() || zSideValue.IsNil() {
+		return aSideValue.IsNil() == zSideValue.IsNil()
+	}
+	if aSideValue.Elem().Type() != zSideValue.Elem().Type() {
+		return false
+	}
+	return this.equal(aSideValue.Elem(), zSideValue.Elem(), state)
+}
+
+// identityComp compares two channels, funcs or unsafe pointers by identity.
+// Two nil values are equal. Funcs are equal if they are the same function,
+// so closures created from the same function literal are considered equal.
+func (this *DeepEqual) identityComp(aSideValue, zSideValue reflect.Value, state *equalState) bool {
+	if aSideValue.IsNil() || zSideValue.IsNil() {
+		return aSideValue.IsNil() == zSideValue.IsNil()
+	}
+	return aSideValue.Pointer() == zSideValue.Pointer()
 }
 
 // ptrComp compares two pointer values by recursively comparing their pointed-to values.
-// Handles nil pointers appropriately.
-func (this *DeepEqual) ptrComp(aSideValue, zSideValue reflect.Value) bool {
+// Handles nil pointers appropriately. A pair of pointers already being compared
+// by an enclosing comparison is considered equal, so cyclic graphs terminate.
+func (this *DeepEqual) ptrComp(aSideValue, zSideValue reflect.Value, state *equalState) bool {
 	if aSideValue.IsNil() && !zSideValue.IsNil() {
 		return false
 	}
@@ -138,42 +285,58 @@ func (this *DeepEqual) ptrComp(aSideValue, zSideValue reflect.Value) bool {
 	if aSideValue.IsNil() && zSideValue.IsNil() {
 		return true
 	}
-	return this.equal(aSideValue.Elem(), zSideValue.Elem())
+	visit, visited := state.enter(aSideValue, zSideValue)
+	if visited {
+		return true
+	}
+	if state.scoped(state.order) {
+		defer state.leave(visit)
+	}
+	return this.equal(aSideValue.Elem(), zSideValue.Elem(), state)
 }
 
 // structComp compares two struct values field by field.
-// Skips fields matching SkipFieldByName criteria.
+// Skips fields matching the field policy.
 // Returns false if struct types don't match.
-func (this *DeepEqual) structComp(aSideValue, zSideValue reflect.Value) bool {
+func (this *DeepEqual) structComp(aSideValue, zSideValue reflect.Value, state *equalState) bool {
 	if aSideValue.Type().Name() != zSideValue.Type().Name() {
 		return false
 	}
-	for i := 0; i < aSideValue.Type().NumField(); i++ {
-		fieldName := aSideValue.Type().Field(i).Name
-		if SkipFieldByName(fieldName) {
+	structType := aSideValue.Type()
+	result := true
+	ignore := state.ignore
+	for i := 0; i < structType.NumField(); i++ {
+		if this.fieldPolicy.SkipField(structType, structType.Field(i)) {
+			continue
+		}
+		fieldIgnore, ignored := ignoreField(ignore, structType.Field(i).Name)
+		if ignored {
 			continue
 		}
 		aFieldValue := aSideValue.Field(i)
 		zFieldValue := zSideValue.Field(i)
-		eq := this.equal(aFieldValue, zFieldValue)
+		state.pushField(structType.Field(i).Name)
+		state.ignore = fieldIgnore
+		state.order = this.sliceOrder(structType, structType.Field(i))
+		eq := this.equal(aFieldValue, zFieldValue, state)
+		state.order = nil
+		state.ignore = ignore
+		state.pop()
 		if !eq {
-			return false
+			if state.done() {
+				return false
+			}
+			result = false
 		}
 	}
-	return true
+	return result
 }
 
 // sliceComp compares two slice values element by element.
 // Returns false if lengths differ or any element differs.
-func (this *DeepEqual) sliceComp(aSideValue, zSideValue reflect.Value) bool {
-	if aSideValue.IsNil() && !zSideValue.IsNil() {
-		return false
-	}
-	if !aSideValue.IsNil() && zSideValue.IsNil() {
-		return false
-	}
-	if aSideValue.IsNil() && zSideValue.IsNil() {
-		return true
+func (this *DeepEqual) sliceComp(aSideValue, zSideValue reflect.Value, state *equalState) bool {
+	if aSideValue.IsNil() || zSideValue.IsNil() {
+		return this.nilComp(aSideValue, zSideValue)
 	}
 
 	// Byte slices are compared atomically.
@@ -181,47 +344,142 @@ func (this *DeepEqual) sliceComp(aSideValue, zSideValue reflect.Value) bool {
 		return bytes.Equal(aSideValue.Bytes(), zSideValue.Bytes())
 	}
 
-	if aSideValue.Len() != zSideValue.Len() {
+	// the order applies to the slice of the field only, not to nested slices
+	order := state.order
+	state.order = nil
+
+	if aSideValue.Len() != zSideValue.Len() && state.report == nil {
 		return false
 	}
+	visit, visited := state.enter(aSideValue, zSideValue)
+	if visited {
+		return true
+	}
+	if state.scoped(order) {
+		defer state.leave(visit)
+	}
+	if order != nil {
+		return this.unorderedComp(aSideValue, zSideValue, order, state)
+	}
+	return this.elemsComp(aSideValue, zSideValue, state)
+}
 
-	for i := 0; i < aSideValue.Len(); i++ {
-		aSideCel := aSideValue.Index(i)
-		zSideCel := zSideValue.Index(i)
-		eq := this.equal(aSideCel, zSideCel)
+// elemsComp compares the elements of two slices or arrays by index.
+// Elements missing on one side are compared to an invalid value, so they are reported.
+func (this *DeepEqual) elemsComp(aSideValue, zSideValue reflect.Value, state *equalState) bool {
+	length := aSideValue.Len()
+	if zSideValue.Len() > length {
+		length = zSideValue.Len()
+	}
+	result := aSideValue.Len() == zSideValue.Len()
+	ignore := state.ignore
+	for i := 0; i < length; i++ {
+		elemIgnore, ignored := ignoreElem(ignore, i)
+		if ignored {
+			continue
+		}
+		var aSideCel, zSideCel reflect.Value
+		if i < aSideValue.Len() {
+			aSideCel = aSideValue.Index(i)
+		}
+		if i < zSideValue.Len() {
+			zSideCel = zSideValue.Index(i)
+		}
+		state.pushIndex(i)
+		state.ignore = elemIgnore
+		eq := this.equal(aSideCel, zSideCel, state)
+		state.ignore = ignore
+		state.pop()
 		if !eq {
-			return false
+			if state.done() {
+				return false
+			}
+			result = false
 		}
 	}
-	return true
+	return result
+}
+
+// nilComp compares two slices or maps of which at least one is nil.
+// A nil container equals an empty one if the NilAsEmpty option is set.
+func (this *DeepEqual) nilComp(aSideValue, zSideValue reflect.Value) bool {
+	if aSideValue.IsNil() && zSideValue.IsNil() {
+		return true
+	}
+	return this.nilAsEmpty() && aSideValue.Len() == 0 && zSideValue.Len() == 0
+}
+
+// arrayComp compares two fixed-size array values element by element.
+// Returns false if lengths differ or any element differs.
+func (this *DeepEqual) arrayComp(aSideValue, zSideValue reflect.Value, state *equalState) bool {
+	if aSideValue.Len() != zSideValue.Len() && state.report == nil {
+		return false
+	}
+	return this.elemsComp(aSideValue, zSideValue, state)
 }
 
 // mapComp compares two map values by comparing all key-value pairs.
 // Returns false if map sizes differ or any key-value pair differs.
-func (this *DeepEqual) mapComp(aSideValue, zSideValue reflect.Value) bool {
-	if aSideValue.IsNil() && !zSideValue.IsNil() {
-		return false
+func (this *DeepEqual) mapComp(aSideValue, zSideValue reflect.Value, state *equalState) bool {
+	if aSideValue.IsNil() || zSideValue.IsNil() {
+		return this.nilComp(aSideValue, zSideValue)
 	}
-	if !aSideValue.IsNil() && zSideValue.IsNil() {
+	if aSideValue.Type().Key() != zSideValue.Type().Key() {
 		return false
 	}
-	if aSideValue.IsNil() && zSideValue.IsNil() {
+	visit, visited := state.enter(aSideValue, zSideValue)
+	if visited {
 		return true
 	}
+	if state.scoped(state.order) {
+		defer state.leave(visit)
+	}
 	mapKeysAside := aSideValue.MapKeys()
 	mapKeysZside := zSideValue.MapKeys()
 
-	if len(mapKeysAside) != len(mapKeysZside) {
+	result := true
+	ignore := state.ignore
+	// keys ignored on either side do not count
+	if state.report == nil && unignoredKeys(ignore, mapKeysAside) != unignoredKeys(ignore, mapKeysZside) {
 		return false
 	}
-
 	for _, key := range mapKeysAside {
+		elemIgnore, ignored := ignoreMapElem(ignore, key)
+		if ignored {
+			continue
+		}
 		aSideV := aSideValue.MapIndex(key)
 		zSideV := zSideValue.MapIndex(key)
-		eq := this.equal(aSideV, zSideV)
+		state.pushMapKey(key)
+		state.ignore = elemIgnore
+		eq := this.equal(aSideV, zSideV, state)
+		state.ignore = ignore
+		state.pop()
 		if !eq {
+			if state.done() {
+				return false
+			}
+			result = false
+		}
+	}
+	if state.report == nil {
+		return result
+	}
+	// keys only present on the z side are reported as missing on the a side
+	for _, key := range mapKeysZside {
+		if aSideValue.MapIndex(key).IsValid() {
+			continue
+		}
+		if _, ignored := ignoreMapElem(ignore, key); ignored {
+			continue
+		}
+		state.pushMapKey(key)
+		this.equal(reflect.Value{}, zSideValue.MapIndex(key), state)
+		state.pop()
+		result = false
+		if state.done() {
 			return false
 		}
 	}
-	return true
+	return result
 }
@@ -0,0 +1,175 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the configurable equality semantics of DeepEqual:
+// float tolerance, NaN handling, nil versus empty containers and ignored property paths.
+
+package cloning
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// EqualOptions relaxes the equality semantics of DeepEqual.
+// The same options are honoured by the Updater's change detection.
+type EqualOptions struct {
+	// FloatAbsTolerance is the maximal absolute difference of floats considered equal
+	FloatAbsTolerance float64
+	// FloatRelTolerance is the maximal difference of floats considered equal,
+	// relative to the larger magnitude of the two
+	FloatRelTolerance float64
+	// NaNEqual makes NaN equal to NaN
+	NaNEqual bool
+	// NilAsEmpty makes nil slices and maps equal to empty ones
+	NilAsEmpty bool
+	// IgnorePaths are property path patterns of the values that are not compared,
+	// e.g. "device.lastseen" or "device.ports<*>.counters". A container without a key
+	// applies the rest of the pattern to all of its elements, as with CloneMask.
+	IgnorePaths []string
+}
+
+// SetOptions sets the equality options, nil restores the exact semantics.
+// Returns an error if an ignored path is malformed.
+func (this *DeepEqual) SetOptions(options *EqualOptions) error {
+	if options == nil {
+		this.options = nil
+		this.ignore = nil
+		return nil
+	}
+	ignore := make(map[string]*cloneMask)
+	for _, path := range options.IgnorePaths {
+		segments, err := maskSegments(path)
+		if err != nil {
+			return err
+		}
+		mask, ok := ignore[segments[0][0]]
+		if !ok {
+			mask = newCloneMask()
+			ignore[segments[0][0]] = mask
+		}
+		if len(segments) == 1 {
+			return errors.New("Ignored path " + path + " has no attribute")
+		}
+		for _, segment := range segments[1:] {
+			mask = mask.child(mask.fields, segment[0])
+			if segment[1] != "" {
+				mask = mask.child(mask.elems, unprefixedKey(segment[1]))
+			}
+		}
+		mask.full = true
+	}
+	for _, mask := range ignore {
+		mask.merge()
+	}
+	this.options = options
+	this.ignore = ignore
+	return nil
+}
+
+// Options returns the equality options, nil if none are set.
+func (this *DeepEqual) Options() *EqualOptions {
+	return this.options
+}
+
+// FloatEqual compares two floats honouring the tolerance and NaN options.
+func (this *DeepEqual) FloatEqual(aSide, zSide float64) bool {
+	if aSide == zSide {
+		return true
+	}
+	if this.options == nil {
+		return false
+	}
+	if math.IsNaN(aSide) || math.IsNaN(zSide) {
+		return this.options.NaNEqual && math.IsNaN(aSide) && math.IsNaN(zSide)
+	}
+	diff := math.Abs(aSide - zSide)
+	if diff <= this.options.FloatAbsTolerance {
+		return true
+	}
+	return diff <= this.options.FloatRelTolerance*math.Max(math.Abs(aSide), math.Abs(zSide))
+}
+
+// nilAsEmpty returns true if nil slices and maps are equal to empty ones.
+func (this *DeepEqual) nilAsEmpty() bool {
+	return this.options != nil && this.options.NilAsEmpty
+}
+
+// IgnoredPath returns true if the property id, e.g. "device.ports<{2}0>.counters",
+// matches one of the ignored paths.
+func (this *DeepEqual) IgnoredPath(propertyId string) bool {
+	if len(this.ignore) == 0 {
+		return false
+	}
+	segments, err := maskSegments(propertyId)
+	if err != nil {
+		return false
+	}
+	mask := this.ignore[segments[0][0]]
+	for _, segment := range segments[1:] {
+		if mask == nil || mask.full {
+			break
+		}
+		mask = mask.fields[strings.ToLower(segment[0])]
+		if mask != nil && segment[1] != "" {
+			mask = mask.elemByKey(segment[1])
+		}
+	}
+	return mask != nil && mask.full
+}
+
+// ignoreField returns the ignore mask of a struct field under the current mask,
+// and whether the field is ignored.
+func ignoreField(mask *cloneMask, name string) (*cloneMask, bool) {
+	if mask == nil {
+		return nil, false
+	}
+	child := mask.fields[strings.ToLower(name)]
+	return child, child != nil && child.full
+}
+
+// ignoreElem returns the ignore mask of a slice or array element under the current mask,
+// and whether the element is ignored.
+func ignoreElem(mask *cloneMask, index int) (*cloneMask, bool) {
+	if mask == nil {
+		return nil, false
+	}
+	child := mask.elem(index)
+	return child, child != nil && child.full
+}
+
+// ignoreMapElem returns the ignore mask of a map element under the current mask,
+// and whether the element is ignored.
+func ignoreMapElem(mask *cloneMask, key reflect.Value) (*cloneMask, bool) {
+	if mask == nil {
+		return nil, false
+	}
+	child := mask.elem(valueInterface(key))
+	return child, child != nil && child.full
+}
+
+// unignoredKeys returns the number of the map keys not ignored under the current mask.
+func unignoredKeys(mask *cloneMask, keys []reflect.Value) int {
+	if mask == nil || len(mask.elems) == 0 {
+		return len(keys)
+	}
+	count := 0
+	for _, key := range keys {
+		if _, ignored := ignoreMapElem(mask, key); !ignored {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,256 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the state of a single DeepEqual comparison and the reporting
+// of the differences between two values by property id.
+
+package cloning
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/saichler/l8reflect/go/reflect/helping"
+)
+
+// Difference is a mismatch found by EqualReport.
+type Difference struct {
+	// PropertyId is the property path of the mismatch, e.g. "device.ports<{2}0>.status"
+	PropertyId string
+	// ASide is the value on the a side, nil if missing or not accessible
+	ASide interface{}
+	// ZSide is the value on the z side, nil if missing or not accessible
+	ZSide interface{}
+}
+
+// equalState holds the state of a single comparison.
+type equalState struct {
+	// root is the lowercase type name starting the property ids
+	root string
+	// path holds the property path segments of the values being compared,
+	// it is only tracked when the differences are reported or the limits checked
+	path []pathSegment
+	// report collects the differences, nil when the comparison stops at the first one
+	report *equalReport
+	// ignore is the mask of the ignored paths under the values being compared
+	ignore *cloneMask
+	// order is the order of the slice field being compared, nil if ordered
+	order *sliceOrder
+	// visits holds the pairs of pointers, slices and maps already being compared
+	visits map[equalVisit]bool
+	// counter checks the comparison against the limits, nil if unlimited
+	counter *limitCounter
+}
+
+// equalVisit is a pair of references compared with each other.
+type equalVisit struct {
+	aSide uintptr
+	zSide uintptr
+	typ   reflect.Type
+	len   int
+}
+
+// equalReport collects the differences up to a limit.
+type equalReport struct {
+	differences []*Difference
+	limit       int
+}
+
+// pathSegment is a struct field, or a slice/array index or map key, of a property path.
+type pathSegment struct {
+	field  string
+	index  int
+	key    reflect.Value
+	hasKey bool
+}
+
+// EqualReport compares two values like Equal and returns their differences, each with the
+// property id (in the format of Property.PropertyId) and both values of the mismatch.
+// Elements and map entries missing on one side are reported with a nil value on that side.
+// At most limit differences are returned, all of them if limit <= 0.
+// An empty result means the values are equal. Panics with a *LimitError like Equal.
+func (this *DeepEqual) EqualReport(aSide, zSide interface{}, limit int) []*Difference {
+	aSideValue := reflect.ValueOf(aSide)
+	zSideValue := reflect.ValueOf(zSide)
+	state := this.newEqualState(aSideValue, zSideValue, &equalReport{differences: make([]*Difference, 0), limit: limit})
+	this.equal(aSideValue, zSideValue, state)
+	return state.report.differences
+}
+
+// newEqualState creates the state of a comparison, with the ignore mask of the root type.
+func (this *DeepEqual) newEqualState(aSideValue, zSideValue reflect.Value, report *equalReport) *equalState {
+	state := &equalState{report: report}
+	if this.limits != nil {
+		state.counter = newLimitCounter(this.limits)
+	}
+	if report == nil && len(this.ignore) == 0 && state.counter == nil {
+		return state
+	}
+	if aSideValue.IsValid() {
+		state.root = rootName(aSideValue.Type())
+	} else if zSideValue.IsValid() {
+		state.root = rootName(zSideValue.Type())
+	}
+	state.ignore = this.ignore[state.root]
+	return state
+}
+
+// rootName returns the lowercase canonical name of the type a value is, or points to.
+func rootName(typ reflect.Type) string {
+	return strings.ToLower(helping.CanonicalTypeName(elemType(typ).Name()))
+}
+
+// enter returns true if the pair of pointers, slices or maps was already visited
+// by this comparison, and marks it as visited otherwise.
+// Slices are identified by their length too, as subslices share their address.
+func (this *equalState) enter(aSideValue, zSideValue reflect.Value) (equalVisit, bool) {
+	visit := equalVisit{aSide: aSideValue.Pointer(), zSide: zSideValue.Pointer(), typ: aSideValue.Type()}
+	if aSideValue.Kind() == reflect.Slice {
+		visit.len = aSideValue.Len()
+	}
+	if this.visits == nil {
+		this.visits = make(map[equalVisit]bool)
+	} else if this.visits[visit] {
+		return visit, true
+	}
+	this.visits[visit] = true
+	return visit, false
+}
+
+// scoped returns true if the comparison of the pair being entered depends on its path,
+// i.e. an ignore mask or a slice order applies to it. Such a pair is only visited while
+// it is being compared (see leave), so a cycle terminates but a pair shared by another
+// path is compared again. Other pairs stay visited: their comparison is the strictest,
+// so an equal pair is equal under any mask and order.
+func (this *equalState) scoped(order *sliceOrder) bool {
+	return this.ignore != nil || order != nil
+}
+
+// leave unmarks a scoped pair marked by enter, once its comparison is done.
+func (this *equalState) leave(visit equalVisit) {
+	delete(this.visits, visit)
+}
+
+// tracked returns true if the current path is tracked.
+func (this *equalState) tracked() bool {
+	return this.report != nil || this.counter != nil
+}
+
+// tentative returns the state of a tentative comparison of the a side element at index,
+// sharing the limits of the comparison in progress but neither its report nor its visits.
+func (this *equalState) tentative(index int, ignore *cloneMask) *equalState {
+	tentative := &equalState{ignore: ignore, counter: this.counter}
+	if this.counter != nil {
+		tentative.root = this.root
+		tentative.path = append(append([]pathSegment{}, this.path...), pathSegment{index: index, hasKey: true})
+	}
+	return tentative
+}
+
+// checkLimits counts both values against the limits, panicking with a LimitError
+// when one is exceeded. A pair of references both already counted, e.g. compared again
+// under another path, pauses the counting until the returned function is called.
+func (this *equalState) checkLimits(aSideValue, zSideValue reflect.Value) func() {
+	aFirst, err := this.counter.check(aSideValue, len(this.path), this.propertyId)
+	zFirst := false
+	if err == nil {
+		zFirst, err = this.counter.check(zSideValue, 0, this.propertyId)
+	}
+	if err != nil {
+		panic(err)
+	}
+	if aFirst || zFirst {
+		return resumeNothing
+	}
+	return this.counter.pause()
+}
+
+// pushField adds a struct field to the current path.
+func (this *equalState) pushField(name string) {
+	if this.tracked() {
+		this.path = append(this.path, pathSegment{field: name})
+	}
+}
+
+// pushIndex adds a slice or array index to the current path.
+func (this *equalState) pushIndex(index int) {
+	if this.tracked() {
+		this.path = append(this.path, pathSegment{index: index, hasKey: true})
+	}
+}
+
+// pushMapKey adds a map key to the current path.
+func (this *equalState) pushMapKey(key reflect.Value) {
+	if this.tracked() {
+		this.path = append(this.path, pathSegment{key: key, hasKey: true})
+	}
+}
+
+// pop removes the last segment of the current path.
+func (this *equalState) pop() {
+	if this.tracked() {
+		this.path = this.path[:len(this.path)-1]
+	}
+}
+
+// done returns true if the comparison should stop at the current mismatch,
+// i.e. when the differences are not reported or the limit was reached.
+func (this *equalState) done() bool {
+	return this.report == nil || (this.report.limit > 0 && len(this.report.differences) >= this.report.limit)
+}
+
+// addDifference reports a mismatch at the current path.
+func (this *equalState) addDifference(aSideValue, zSideValue reflect.Value) {
+	if this.done() {
+		return
+	}
+	this.report.differences = append(this.report.differences, &Difference{
+		PropertyId: this.propertyId(),
+		ASide:      valueInterface(aSideValue),
+		ZSide:      valueInterface(zSideValue),
+	})
+}
+
+// propertyId renders the current path, e.g. "device.ports<{2}0>.status".
+func (this *equalState) propertyId() string {
+	return renderPath(this.root, this.path)
+}
+
+// renderPath renders a property path from its root type name and segments.
+func renderPath(root string, path []pathSegment) string {
+	buff := strings.Builder{}
+	buff.WriteString(root)
+	for _, segment := range path {
+		if !segment.hasKey {
+			buff.WriteString(".")
+			buff.WriteString(strings.ToLower(segment.field))
+			continue
+		}
+		buff.WriteString("<")
+		if segment.key.IsValid() {
+			buff.WriteString(helping.KeyString(valueInterface(segment.key)))
+		} else {
+			buff.WriteString(helping.KeyString(segment.index))
+		}
+		buff.WriteString(">")
+	}
+	return buff.String()
+}
+
+// valueInterface returns the value as an interface, or nil if it is invalid or not accessible.
+func valueInterface(value reflect.Value) interface{} {
+	if !value.IsValid() || !value.CanInterface() {
+		return nil
+	}
+	return value.Interface()
+}
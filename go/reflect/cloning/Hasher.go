@@ -0,0 +1,313 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the deterministic structural hashing of values, for ETags,
+// cheap change detection before running the Updater and deduplication in replication.
+
+package cloning
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// Hasher produces a stable hash of a value's contents, consistent with a DeepEqual:
+// acyclic values it considers equal have the same hash. The hash only depends on the values,
+// using FNV-1a and a fixed encoding, so it is the same across processes and Go versions.
+//
+// The DeepEqual's field policy, ignored paths, nil-as-empty and NaN options and slice
+// order policy are honoured. Float tolerances cannot be honoured by a hash, so floats
+// within the tolerance may hash differently. Map entries and unordered slices are hashed
+// regardless of their order, and channels, funcs and unsafe pointers only by their nil-ness.
+// DeepEqualer implementations are ignored, their values are hashed field by field.
+//
+// Cyclic values are hashed too, with the references back to an object being hashed
+// written as their distance to it. Values with cycles of the same shape hash the same,
+// but equal values with cycles of different lengths may not, e.g. a node pointing to
+// itself and two equal nodes pointing to each other, which DeepEqual considers equal.
+type Hasher struct {
+	// deepEqual holds the equality semantics the hash is consistent with
+	deepEqual *DeepEqual
+}
+
+// hashState holds the state of hashing a single value.
+type hashState struct {
+	hash    hash.Hash
+	newHash func() hash.Hash
+	// ancestors maps the references being hashed to their depth
+	ancestors map[cloneKey]int
+	depth     int
+	// ignore is the mask of the ignored paths under the value being hashed
+	ignore *cloneMask
+	// order is the order of the slice field being hashed, nil if ordered
+	order *sliceOrder
+	buff  [8]byte
+}
+
+// NewHasher creates a Hasher consistent with the given DeepEqual,
+// or with a default DeepEqual if it is nil.
+func NewHasher(deepEqual *DeepEqual) *Hasher {
+	if deepEqual == nil {
+		deepEqual = NewDeepEqual()
+	}
+	return &Hasher{deepEqual: deepEqual}
+}
+
+// Hash64 returns the 64-bit FNV-1a structural hash of the value.
+func (this *Hasher) Hash64(any interface{}) uint64 {
+	return binary.BigEndian.Uint64(this.hash(any, func() hash.Hash { return fnv.New64a() }))
+}
+
+// Hash128 returns the 128-bit FNV-1a structural hash of the value.
+func (this *Hasher) Hash128(any interface{}) [16]byte {
+	var result [16]byte
+	copy(result[:], this.hash(any, fnv.New128a))
+	return result
+}
+
+// hash walks the value into a new hash and returns its digest.
+func (this *Hasher) hash(any interface{}, newHash func() hash.Hash) []byte {
+	value := reflect.ValueOf(any)
+	state := &hashState{hash: newHash(), newHash: newHash, ancestors: make(map[cloneKey]int)}
+	if value.IsValid() {
+		state.ignore = this.deepEqual.ignore[rootName(value.Type())]
+	}
+	this.write(value, state)
+	return state.hash.Sum(nil)
+}
+
+// write writes the encoding of a value, prefixed by its kind.
+func (this *Hasher) write(value reflect.Value, state *hashState) {
+	if !value.IsValid() {
+		state.writeByte(byte(reflect.Invalid))
+		return
+	}
+	state.writeByte(byte(value.Kind()))
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		state.writeUint(uint64(value.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		state.writeUint(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		this.writeFloat(value.Float(), state)
+	case reflect.Complex64, reflect.Complex128:
+		this.writeFloat(real(value.Complex()), state)
+		this.writeFloat(imag(value.Complex()), state)
+	case reflect.Bool:
+		if value.Bool() {
+			state.writeByte(1)
+		} else {
+			state.writeByte(0)
+		}
+	case reflect.String:
+		state.writeString(value.String())
+	case reflect.Ptr:
+		this.writeRef(value, state, func() { this.write(value.Elem(), state) })
+	case reflect.Interface:
+		if value.IsNil() {
+			state.writeByte(0)
+			return
+		}
+		state.writeByte(1)
+		state.writeString(value.Elem().Type().String())
+		this.write(value.Elem(), state)
+	case reflect.Struct:
+		this.writeStruct(value, state)
+	case reflect.Slice:
+		order := state.order
+		state.order = nil
+		if value.IsNil() || value.Len() == 0 {
+			this.writeEmpty(value, state)
+			return
+		}
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			state.writeUint(uint64(value.Len()))
+			state.hash.Write(value.Bytes())
+			return
+		}
+		this.writeRef(value, state, func() {
+			if order != nil {
+				this.writeUnordered(value, state)
+				return
+			}
+			this.writeElems(value, state)
+		})
+	case reflect.Array:
+		this.writeElems(value, state)
+	case reflect.Map:
+		if value.IsNil() || value.Len() == 0 {
+			this.writeEmpty(value, state)
+			return
+		}
+		this.writeRef(value, state, func() { this.writeMap(value, state) })
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if value.IsNil() {
+			state.writeByte(0)
+		} else {
+			state.writeByte(1)
+		}
+	}
+}
+
+// writeFloat writes a float, with 0 and -0, and NaNs if NaN equals NaN, written the same.
+func (this *Hasher) writeFloat(f float64, state *hashState) {
+	switch {
+	case f == 0:
+		f = 0
+	case math.IsNaN(f) && this.deepEqual.options != nil && this.deepEqual.options.NaNEqual:
+		f = math.NaN()
+	}
+	state.writeUint(math.Float64bits(f))
+}
+
+// writeEmpty writes a nil or empty slice or map, both written the same if nil is empty.
+func (this *Hasher) writeEmpty(value reflect.Value, state *hashState) {
+	if value.IsNil() && !this.deepEqual.nilAsEmpty() {
+		state.writeByte(0)
+		return
+	}
+	state.writeByte(1)
+	state.writeUint(0)
+}
+
+// writeRef writes a non nil pointer, slice or map, or the distance to its
+// ancestor if it is already being hashed.
+func (this *Hasher) writeRef(value reflect.Value, state *hashState, write func()) {
+	if value.Kind() == reflect.Ptr && value.IsNil() {
+		state.writeByte(0)
+		return
+	}
+	key := cloneKey{ptr: value.Pointer(), typ: value.Type()}
+	if value.Kind() == reflect.Slice {
+		key.len = value.Len()
+	}
+	depth, ok := state.ancestors[key]
+	if ok {
+		state.writeByte(2)
+		state.writeUint(uint64(state.depth - depth))
+		return
+	}
+	state.writeByte(1)
+	state.depth++
+	state.ancestors[key] = state.depth
+	write()
+	delete(state.ancestors, key)
+	state.depth--
+}
+
+// writeStruct writes the struct's name and the fields that are compared by the DeepEqual.
+func (this *Hasher) writeStruct(value reflect.Value, state *hashState) {
+	structType := value.Type()
+	state.writeString(structType.Name())
+	ignore := state.ignore
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if this.deepEqual.fieldPolicy.SkipField(structType, field) {
+			continue
+		}
+		fieldIgnore, ignored := ignoreField(ignore, field.Name)
+		if ignored {
+			continue
+		}
+		state.writeUint(uint64(i))
+		state.ignore = fieldIgnore
+		state.order = this.deepEqual.sliceOrder(structType, field)
+		this.write(value.Field(i), state)
+		state.order = nil
+		state.ignore = ignore
+	}
+}
+
+// writeElems writes the elements of a slice or array in order.
+func (this *Hasher) writeElems(value reflect.Value, state *hashState) {
+	state.writeUint(uint64(value.Len()))
+	ignore := state.ignore
+	for i := 0; i < value.Len(); i++ {
+		elemIgnore, ignored := ignoreElem(ignore, i)
+		if ignored {
+			state.writeByte(0)
+			continue
+		}
+		state.writeByte(1)
+		state.ignore = elemIgnore
+		this.write(value.Index(i), state)
+		state.ignore = ignore
+	}
+}
+
+// writeUnordered writes the sorted digests of the elements of an unordered slice.
+func (this *Hasher) writeUnordered(value reflect.Value, state *hashState) {
+	digests := make([][]byte, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		elemIgnore, ignored := ignoreElem(state.ignore, i)
+		if ignored {
+			continue
+		}
+		digests = append(digests, this.digest(state, elemIgnore, value.Index(i)))
+	}
+	state.writeDigests(digests)
+}
+
+// writeMap writes the sorted digests of the map entries.
+func (this *Hasher) writeMap(value reflect.Value, state *hashState) {
+	digests := make([][]byte, 0, value.Len())
+	iter := value.MapRange()
+	for iter.Next() {
+		elemIgnore, ignored := ignoreMapElem(state.ignore, iter.Key())
+		if ignored {
+			continue
+		}
+		digests = append(digests, this.digest(state, elemIgnore, iter.Key(), iter.Value()))
+	}
+	state.writeDigests(digests)
+}
+
+// digest returns the digest of the values hashed on their own, sharing the ancestors of the state.
+func (this *Hasher) digest(state *hashState, ignore *cloneMask, values ...reflect.Value) []byte {
+	sub := &hashState{hash: state.newHash(), newHash: state.newHash, ancestors: state.ancestors, depth: state.depth, ignore: ignore}
+	for _, value := range values {
+		this.write(value, sub)
+	}
+	return sub.hash.Sum(nil)
+}
+
+// writeDigests writes the digests sorted, so their original order does not matter.
+func (this *hashState) writeDigests(digests [][]byte) {
+	sort.Slice(digests, func(i, j int) bool {
+		return bytes.Compare(digests[i], digests[j]) < 0
+	})
+	this.writeUint(uint64(len(digests)))
+	for _, digest := range digests {
+		this.hash.Write(digest)
+	}
+}
+
+func (this *hashState) writeByte(b byte) {
+	this.buff[0] = b
+	this.hash.Write(this.buff[:1])
+}
+
+func (this *hashState) writeUint(u uint64) {
+	binary.LittleEndian.PutUint64(this.buff[:], u)
+	this.hash.Write(this.buff[:])
+}
+
+func (this *hashState) writeString(s string) {
+	this.writeUint(uint64(len(s)))
+	this.hash.Write([]byte(s))
+}
@@ -0,0 +1,339 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the limits guarding the Cloner, DeepEqual and Updater against
+// hostile or oversized inputs, e.g. deeply nested payloads or huge maps.
+
+package cloning
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/saichler/l8reflect/go/reflect/helping"
+)
+
+// Limits bounds the work done on a single input. A zero field means no limit.
+type Limits struct {
+	// MaxDepth is the maximal depth of the property path, counting each struct field
+	// and each slice, array or map element
+	MaxDepth int
+	// MaxElements is the maximal total number of slice, array and map elements
+	MaxElements int64
+	// MaxBytes is the maximal total number of bytes of strings, slices, maps and
+	// pointed-to values, as estimated by Size
+	MaxBytes int64
+}
+
+// LimitError is the error of an input exceeding one of the Limits.
+type LimitError struct {
+	// Limit is the name of the exceeded limit: "depth", "elements" or "bytes"
+	Limit string
+	// Max is the value of the exceeded limit
+	Max int64
+	// PropertyId is the property path of the value exceeding the limit
+	PropertyId string
+}
+
+// Error returns the description of the exceeded limit.
+func (this *LimitError) Error() string {
+	return "Limit of " + strconv.FormatInt(this.Max, 10) + " " + this.Limit + " exceeded at " + this.PropertyId
+}
+
+// limitCounter counts the depth, elements and bytes of a single walk against Limits.
+// Pointers, slices and maps are counted once, however many times they are referenced.
+type limitCounter struct {
+	limits   *Limits
+	elements int64
+	bytes    int64
+	// counted holds the pointers, slices and maps already counted
+	counted map[cloneKey]bool
+	// paused is the number of walks of references already counted in progress,
+	// nothing is counted under them
+	paused int
+}
+
+// newLimitCounter creates a counter for a walk bounded by the limits.
+func newLimitCounter(limits *Limits) *limitCounter {
+	return &limitCounter{limits: limits, counted: make(map[cloneKey]bool)}
+}
+
+// check checks the depth of the value and adds its elements and bytes to the counts.
+// Returns false if the value is a pointer, slice or map already counted, or the counting
+// is paused, in which case nothing is checked. Returns a LimitError with the property id
+// returned by path if a limit is exceeded.
+func (this *limitCounter) check(value reflect.Value, depth int, path func() string) (bool, error) {
+	if this.paused > 0 {
+		return false, nil
+	}
+	if this.limits.MaxDepth > 0 && depth > this.limits.MaxDepth {
+		return true, &LimitError{Limit: "depth", Max: int64(this.limits.MaxDepth), PropertyId: path()}
+	}
+	if !value.IsValid() {
+		return true, nil
+	}
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		if !value.IsNil() && !this.count(value) {
+			return false, nil
+		}
+	}
+	switch value.Kind() {
+	case reflect.String:
+		this.bytes += int64(value.Len())
+	case reflect.Ptr:
+		if !value.IsNil() {
+			this.bytes += int64(value.Type().Elem().Size())
+		}
+	case reflect.Slice:
+		this.elements += int64(value.Len())
+		this.bytes += int64(value.Len()) * int64(value.Type().Elem().Size())
+	case reflect.Array:
+		this.elements += int64(value.Len())
+	case reflect.Map:
+		if !value.IsNil() {
+			this.elements += int64(value.Len())
+			this.bytes += mapSize(value.Type(), value.Len())
+		}
+	}
+	if this.limits.MaxElements > 0 && this.elements > this.limits.MaxElements {
+		return true, &LimitError{Limit: "elements", Max: this.limits.MaxElements, PropertyId: path()}
+	}
+	if this.limits.MaxBytes > 0 && this.bytes > this.limits.MaxBytes {
+		return true, &LimitError{Limit: "bytes", Max: this.limits.MaxBytes, PropertyId: path()}
+	}
+	return true, nil
+}
+
+// count returns true the first time a pointer, slice or map is counted.
+// Slices are identified by their length too, as subslices share their address.
+func (this *limitCounter) count(value reflect.Value) bool {
+	key := cloneKey{ptr: value.Pointer(), typ: value.Type()}
+	if value.Kind() == reflect.Slice {
+		key.len = value.Len()
+	}
+	if this.counted[key] {
+		return false
+	}
+	this.counted[key] = true
+	return true
+}
+
+// pause stops the counting while a reference already counted is walked again,
+// returning the function resuming it.
+func (this *limitCounter) pause() func() {
+	this.paused++
+	return func() { this.paused-- }
+}
+
+// resumeNothing is returned by the checks of values that did not pause the counting.
+func resumeNothing() {}
+
+// limitWalk walks a value graph, checking it against Limits without cloning it.
+type limitWalk struct {
+	counter *limitCounter
+	root    string
+	path    []pathSegment
+}
+
+// Check walks the value like the Cloner does, returning a LimitError if it exceeds the limits.
+// Shared pointers, slices and maps are counted once, map keys count with their values.
+// It validates an input before it is used, e.g. by the Updater before updating anything.
+func (this *Limits) Check(any interface{}) error {
+	if any == nil {
+		return nil
+	}
+	value := reflect.ValueOf(any)
+	walk := &limitWalk{counter: newLimitCounter(this), root: rootName(value.Type())}
+	return walk.walk(value)
+}
+
+// walk checks the value and everything it references.
+func (this *limitWalk) walk(value reflect.Value) error {
+	if !value.IsValid() {
+		return nil
+	}
+	first, err := this.counter.check(value, len(this.path), this.propertyId)
+	if err != nil || !first {
+		return err
+	}
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil
+		}
+		return this.walk(value.Elem())
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return this.walk(value.Elem())
+	case reflect.Struct:
+		structType := value.Type()
+		for i := 0; i < structType.NumField(); i++ {
+			if helping.DefaultFieldPolicy.SkipField(structType, structType.Field(i)) {
+				continue
+			}
+			err = this.walkSegment(pathSegment{field: structType.Field(i).Name}, value.Field(i))
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return nil
+		}
+		for i := 0; i < value.Len(); i++ {
+			err = this.walkSegment(pathSegment{index: i, hasKey: true}, value.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if value.IsNil() {
+			return nil
+		}
+		iter := value.MapRange()
+		for iter.Next() {
+			err = this.walkSegment(pathSegment{key: iter.Key(), hasKey: true}, iter.Key(), iter.Value())
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// walkSegment walks the values under a path segment.
+func (this *limitWalk) walkSegment(segment pathSegment, values ...reflect.Value) error {
+	this.path = append(this.path, segment)
+	defer func() { this.path = this.path[:len(this.path)-1] }()
+	for _, value := range values {
+		err := this.walk(value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// propertyId renders the current path.
+func (this *limitWalk) propertyId() string {
+	return renderPath(this.root, this.path)
+}
+
+// recoverError turns a panic into an error, e.g. a LimitError raised deep in a walk.
+// A LimitError is returned as is, other panics are described with the given prefix.
+func recoverError(r interface{}, prefix string) error {
+	limitErr, ok := r.(*LimitError)
+	if ok {
+		return limitErr
+	}
+	return fmt.Errorf("%s: %v", prefix, r)
+}
+
+// recoverLimit recovers a LimitError panic into err, other panics are raised again.
+// It must be deferred directly.
+func recoverLimit(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	limitErr, ok := r.(*LimitError)
+	if !ok {
+		panic(r)
+	}
+	*err = limitErr
+}
+
+// SetLimits sets the limits of the values cloned by Clone, CloneWithError, CopyInto and
+// CloneMask, nil for no limits. Values are cloned sequentially while limits are set, and
+// DeepCloner types reflectively, so their values are counted; CloneFuncs registered with
+// RegisterCloneFunc still apply and are not counted. It must be called before the Cloner is used.
+func (this *Cloner) SetLimits(limits *Limits) {
+	this.limits = limits
+}
+
+// CloneWithError clones the value like Clone, but returns an error instead of panicking,
+// e.g. a *LimitError when the value exceeds the limits set by SetLimits.
+func (this *Cloner) CloneWithError(any interface{}) (clone interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			clone = nil
+			err = recoverError(r, "Clone")
+		}
+	}()
+	return this.Clone(any), nil
+}
+
+// limitLoop makes the cloneLoop of the value check the limits, if set.
+func (this *Cloner) limitLoop(stopLoop *cloneLoop, value reflect.Value) {
+	if this.limits == nil {
+		return
+	}
+	stopLoop.counter = newLimitCounter(this.limits)
+	stopLoop.root = rootName(value.Type())
+}
+
+// pushField adds a struct field to the path of the value being cloned.
+func (this *cloneLoop) pushField(name string) {
+	if this.counter != nil {
+		this.path = append(this.path, pathSegment{field: name})
+	}
+}
+
+// pushIndex adds a slice or array index to the path of the value being cloned.
+func (this *cloneLoop) pushIndex(index int) {
+	if this.counter != nil {
+		this.path = append(this.path, pathSegment{index: index, hasKey: true})
+	}
+}
+
+// pushMapKey adds a map key to the path of the value being cloned.
+func (this *cloneLoop) pushMapKey(key reflect.Value) {
+	if this.counter != nil {
+		this.path = append(this.path, pathSegment{key: key, hasKey: true})
+	}
+}
+
+// pop removes the last segment of the path of the value being cloned.
+func (this *cloneLoop) pop() {
+	if this.counter != nil {
+		this.path = this.path[:len(this.path)-1]
+	}
+}
+
+// checkLimits counts the value against the limits, panicking with a LimitError
+// when one is exceeded. A reference already counted, e.g. a shared slice cloned again,
+// is not counted again, as by Limits.Check: the counting is paused while it is cloned,
+// until the returned function is called.
+func (this *cloneLoop) checkLimits(value reflect.Value) func() {
+	first, err := this.counter.check(value, len(this.path), func() string {
+		return renderPath(this.root, this.path)
+	})
+	if err != nil {
+		panic(err)
+	}
+	if first {
+		return resumeNothing
+	}
+	return this.counter.pause()
+}
+
+// SetLimits sets the limits of the values compared, nil for no limits.
+// Each comparison counts the values of both sides. DeepEqualer types are compared
+// reflectively while limits are set, so their values are counted.
+func (this *DeepEqual) SetLimits(limits *Limits) {
+	this.limits = limits
+}
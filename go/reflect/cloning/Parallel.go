@@ -0,0 +1,141 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the tracking of cloned references and the parallel cloning
+// of large slices and maps across a bounded pool of goroutines.
+
+package cloning
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+// cloneLoop tracks the references already cloned, to handle cycles and shared references.
+// It is guarded by a mutex only when the clone runs in parallel.
+// A clone checked against limits runs sequentially and tracks its path.
+type cloneLoop struct {
+	refs    map[cloneKey]reflect.Value
+	mutex   *sync.Mutex
+	counter *limitCounter
+	root    string
+	path    []pathSegment
+	claims  *copyClaims
+}
+
+// newCloneLoop creates a cloneLoop, goroutine-safe if parallel is true.
+func newCloneLoop(parallel bool) *cloneLoop {
+	loop := &cloneLoop{refs: make(map[cloneKey]reflect.Value)}
+	if parallel {
+		loop.mutex = &sync.Mutex{}
+	}
+	return loop
+}
+
+// load returns the clone of a reference, if it was already cloned.
+func (this *cloneLoop) load(key cloneKey) (reflect.Value, bool) {
+	if this.mutex != nil {
+		this.mutex.Lock()
+		defer this.mutex.Unlock()
+	}
+	value, ok := this.refs[key]
+	return value, ok
+}
+
+// loadOrStore returns the existing clone of a reference and true, or stores the
+// given clone and returns it and false. Only the goroutine whose clone was stored
+// may fill it, so a reference is cloned once even when reached concurrently.
+func (this *cloneLoop) loadOrStore(key cloneKey, value reflect.Value) (reflect.Value, bool) {
+	if this.mutex != nil {
+		this.mutex.Lock()
+		defer this.mutex.Unlock()
+	}
+	exist, ok := this.refs[key]
+	if ok {
+		return exist, true
+	}
+	this.refs[key] = value
+	return value, false
+}
+
+// SetParallel makes the Cloner clone the elements of slices and maps holding at least
+// threshold elements in parallel, using at most workers additional goroutines across
+// all the concurrent Clone calls of this Cloner. When all the workers are busy,
+// the elements are cloned by the calling goroutine.
+// A workers value <= 0 uses GOMAXPROCS, a threshold <= 0 disables parallel cloning.
+// It must be called before the Cloner is used.
+func (this *Cloner) SetParallel(threshold, workers int) {
+	if threshold <= 0 {
+		this.parallelThreshold = 0
+		this.parallelSlots = nil
+		return
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	this.parallelThreshold = threshold
+	this.parallelSlots = make(chan struct{}, workers)
+}
+
+// isParallel returns true if a container of the given length is cloned in parallel,
+// which requires a goroutine-safe cloneLoop.
+func (this *Cloner) isParallel(stopLoop *cloneLoop, length int) bool {
+	return stopLoop.mutex != nil && this.parallelThreshold > 0 && length >= this.parallelThreshold
+}
+
+// forRange calls do for the index range [0, length). Above the parallel threshold the range
+// is split into chunks, handed to free workers or run by the calling goroutine otherwise.
+// A panic in any chunk is raised again in the calling goroutine once all chunks are done.
+func (this *Cloner) forRange(stopLoop *cloneLoop, length int, do func(from, to int)) {
+	if !this.isParallel(stopLoop, length) {
+		do(0, length)
+		return
+	}
+	chunks := cap(this.parallelSlots) + 1
+	size := (length + chunks - 1) / chunks
+	wg := &sync.WaitGroup{}
+	mutex := &sync.Mutex{}
+	var panicValue interface{}
+	run := func(from, to int) {
+		defer func() {
+			if r := recover(); r != nil {
+				mutex.Lock()
+				panicValue = r
+				mutex.Unlock()
+			}
+		}()
+		do(from, to)
+	}
+	for from := 0; from < length; from += size {
+		to := from + size
+		if to > length {
+			to = length
+		}
+		select {
+		case this.parallelSlots <- struct{}{}:
+			wg.Add(1)
+			go func(from, to int) {
+				defer wg.Done()
+				defer func() { <-this.parallelSlots }()
+				run(from, to)
+			}(from, to)
+		default:
+			run(from, to)
+		}
+	}
+	wg.Wait()
+	if panicValue != nil {
+		panic(panicValue)
+	}
+}
@@ -0,0 +1,315 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the estimation of the memory held by an object graph,
+// e.g. for cache admission, with an optional breakdown per property path.
+
+package cloning
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	// mapHeaderSize is the approximate size of a map header
+	mapHeaderSize = 48
+	// mapBucketSlots is the number of entries of a map bucket
+	mapBucketSlots = 8
+	// mapLoadFactor is the average number of entries per bucket before a map grows
+	mapLoadFactor = 6.5
+	// chanHeaderSize is the approximate size of a channel header
+	chanHeaderSize = 96
+	// addrPageShift is the log2 of the size of the pages indexing the counted address ranges
+	addrPageShift = 12
+)
+
+// sizeState holds the state of a single size estimation.
+type sizeState struct {
+	// ranges holds the memory of the pointed-to values and backing arrays already counted
+	ranges addrRanges
+	// visited holds the maps, channels and zero sized references already counted
+	visited map[cloneKey]bool
+	// paths maps the property paths to the bytes held under them, nil without breakdown
+	paths map[string]int64
+	// maxDepth is the number of path segments of the breakdown
+	maxDepth int
+	total    int64
+}
+
+// Size estimates the number of bytes held by the value and everything it references.
+// The graph is walked like the Cloner does: shared memory is counted once, including
+// pointers into struct fields or slice elements and subslices, slices count their capacity
+// and strings their data. Map sizes are an estimate of the runtime's buckets.
+func Size(any interface{}) int64 {
+	total, _ := SizeByPath(any, 0)
+	return total
+}
+
+// SizeByPath estimates the size of the value like Size and breaks it down by property path,
+// e.g. "device.ports.counters", up to maxDepth fields below the root (no breakdown if <= 0).
+// Elements of slices, arrays and maps share the path of their container, so the breakdown
+// shows which fields dominate, whatever the number of elements. Each path holds the bytes of
+// its field and everything under it, bytes shared by several paths count for the first one.
+func SizeByPath(any interface{}, maxDepth int) (int64, map[string]int64) {
+	if any == nil {
+		return 0, nil
+	}
+	value := reflect.ValueOf(any)
+	state := &sizeState{ranges: make(addrRanges), visited: make(map[cloneKey]bool), maxDepth: maxDepth}
+	root := ""
+	if maxDepth > 0 {
+		state.paths = make(map[string]int64)
+		root = rootName(value.Type())
+	}
+	state.total = int64(value.Type().Size())
+	state.indirect(value, root, 0)
+	if state.paths != nil {
+		state.paths[root] = state.total
+	}
+	return state.total, state.paths
+}
+
+// indirect adds the bytes referenced by the value, excluding the value itself.
+func (this *sizeState) indirect(value reflect.Value, path string, depth int) {
+	switch value.Kind() {
+	case reflect.String:
+		this.total += int64(value.Len())
+	case reflect.Ptr:
+		if value.IsNil() {
+			return
+		}
+		this.walkRange(value, value.Type().Elem().Size(), func(walk func(reflect.Value, string, int)) {
+			walk(value.Elem(), path, depth)
+		})
+	case reflect.Interface:
+		if value.IsNil() {
+			return
+		}
+		elem := value.Elem()
+		switch elem.Kind() {
+		case reflect.Ptr, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		default:
+			// non pointer values are boxed
+			this.total += int64(elem.Type().Size())
+		}
+		this.indirect(elem, path, depth)
+	case reflect.Struct:
+		structType := value.Type()
+		for i := 0; i < structType.NumField(); i++ {
+			this.field(value.Field(i), structType.Field(i), path, depth, this.indirect)
+		}
+	case reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			this.indirect(value.Index(i), path, depth)
+		}
+	case reflect.Slice:
+		if value.IsNil() || value.Cap() == 0 {
+			return
+		}
+		this.walkRange(value, uintptr(value.Cap())*value.Type().Elem().Size(), func(walk func(reflect.Value, string, int)) {
+			for i := 0; i < value.Len(); i++ {
+				walk(value.Index(i), path, depth)
+			}
+		})
+	case reflect.Map:
+		if value.IsNil() || !this.visit(value) {
+			return
+		}
+		this.total += mapSize(value.Type(), value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			this.indirect(iter.Key(), path, depth)
+			this.indirect(iter.Value(), path, depth)
+		}
+	case reflect.Chan:
+		if value.IsNil() || !this.visit(value) {
+			return
+		}
+		this.total += chanHeaderSize + int64(value.Cap())*int64(value.Type().Elem().Size())
+	}
+}
+
+// field adds the bytes referenced by a struct field using walk, attributing them and the
+// field's own bytes to the field's path when breaking down.
+func (this *sizeState) field(value reflect.Value, field reflect.StructField, path string, depth int,
+	walk func(reflect.Value, string, int)) {
+	if this.paths == nil || depth >= this.maxDepth {
+		walk(value, path, depth)
+		return
+	}
+	fieldPath := path + "." + strings.ToLower(field.Name)
+	before := this.total
+	walk(value, fieldPath, depth+1)
+	this.paths[fieldPath] += this.total - before + int64(field.Type.Size())
+}
+
+// walkRange counts the bytes of the memory of size bytes a pointer or slice references
+// that were not counted yet, and walks the values stored there. Values are walked with
+// indirect if none of the memory was counted before, or else with partial, so only
+// the parts not walked yet are. The memory is recorded before it is walked, so cycles
+// back to it end. Zero sized memory is walked once per reference.
+func (this *sizeState) walkRange(ref reflect.Value, size uintptr, walkValues func(func(reflect.Value, string, int))) {
+	if size == 0 {
+		if this.visit(ref) {
+			walkValues(this.indirect)
+		}
+		return
+	}
+	start := ref.Pointer()
+	covered := this.ranges.covered(start, start+size)
+	if covered == size {
+		return
+	}
+	this.total += int64(size - covered)
+	if covered == 0 {
+		this.ranges.add(start, start+size)
+		walkValues(this.indirect)
+		return
+	}
+	prior := this.ranges.clip(start, start+size)
+	this.ranges.add(start, start+size)
+	walkValues(this.partial(prior))
+}
+
+// partial returns the walk of addressable values of which the parts in prior were
+// already walked, walking only the fields and elements that were not.
+func (this *sizeState) partial(prior addrRanges) func(reflect.Value, string, int) {
+	var walk func(reflect.Value, string, int)
+	walk = func(value reflect.Value, path string, depth int) {
+		start := value.UnsafeAddr()
+		size := value.Type().Size()
+		covered := prior.covered(start, start+size)
+		if size > 0 && covered == size {
+			return
+		}
+		if covered == 0 {
+			this.indirect(value, path, depth)
+			return
+		}
+		switch value.Kind() {
+		case reflect.Struct:
+			structType := value.Type()
+			for i := 0; i < structType.NumField(); i++ {
+				this.field(value.Field(i), structType.Field(i), path, depth, walk)
+			}
+		case reflect.Array:
+			for i := 0; i < value.Len(); i++ {
+				walk(value.Index(i), path, depth)
+			}
+		default:
+			this.indirect(value, path, depth)
+		}
+	}
+	return walk
+}
+
+// visit returns true the first time a reference is visited.
+func (this *sizeState) visit(value reflect.Value) bool {
+	key := cloneKey{ptr: value.Pointer(), typ: value.Type()}
+	if this.visited[key] {
+		return false
+	}
+	this.visited[key] = true
+	return true
+}
+
+// mapSize estimates the bytes of the buckets of a map with the given number of entries.
+func mapSize(mapType reflect.Type, length int) int64 {
+	buckets := int64(1)
+	for float64(buckets)*mapLoadFactor < float64(length) {
+		buckets *= 2
+	}
+	slot := int64(mapType.Key().Size() + mapType.Elem().Size())
+	// each bucket holds its slots, their top hashes and an overflow pointer
+	bucket := mapBucketSlots*(slot+1) + 8
+	return mapHeaderSize + buckets*bucket
+}
+
+// addrRanges is a set of address ranges, indexed by page so adding a range only
+// merges it with the ranges of its pages.
+type addrRanges map[uintptr][]addrRange
+
+// addrRange is the memory from start up to end, excluded.
+type addrRange struct {
+	start uintptr
+	end   uintptr
+}
+
+// covered returns the number of bytes from start up to end that are in the set.
+func (this addrRanges) covered(start, end uintptr) uintptr {
+	covered := uintptr(0)
+	for page := start >> addrPageShift; page <= (end-1)>>addrPageShift; page++ {
+		for _, r := range this[page] {
+			from, to := r.start, r.end
+			if from < start {
+				from = start
+			}
+			if to > end {
+				to = end
+			}
+			if from < to {
+				covered += to - from
+			}
+		}
+	}
+	return covered
+}
+
+// clip returns the parts of the ranges of the set from start up to end.
+func (this addrRanges) clip(start, end uintptr) addrRanges {
+	clipped := make(addrRanges)
+	for page := start >> addrPageShift; page <= (end-1)>>addrPageShift; page++ {
+		for _, r := range this[page] {
+			if r.start < start {
+				r.start = start
+			}
+			if r.end > end {
+				r.end = end
+			}
+			if r.start < r.end {
+				clipped[page] = append(clipped[page], r)
+			}
+		}
+	}
+	return clipped
+}
+
+// add adds the range from start up to end to the set, merging it with the
+// ranges it overlaps or touches.
+func (this addrRanges) add(start, end uintptr) {
+	for page := start >> addrPageShift; page <= (end-1)>>addrPageShift; page++ {
+		from, to := page<<addrPageShift, (page+1)<<addrPageShift
+		if from < start {
+			from = start
+		}
+		if to > end {
+			to = end
+		}
+		ranges := append(this[page], addrRange{start: from, end: to})
+		sort.Slice(ranges, func(i, j int) bool { return ranges[i].start < ranges[j].start })
+		merged := ranges[:1]
+		for _, r := range ranges[1:] {
+			last := &merged[len(merged)-1]
+			if r.start <= last.end {
+				if r.end > last.end {
+					last.end = r.end
+				}
+				continue
+			}
+			merged = append(merged, r)
+		}
+		this[page] = merged
+	}
+}
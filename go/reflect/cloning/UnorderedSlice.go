@@ -0,0 +1,214 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the order-insensitive comparison of slices that are logically
+// sets or keyed lists, as decided by a helping.SliceOrderPolicy.
+
+package cloning
+
+import (
+	"reflect"
+
+	"github.com/saichler/l8reflect/go/reflect/helping"
+)
+
+// sliceOrder is the order of an unordered slice field.
+type sliceOrder struct {
+	// keyFields identifies the struct elements, empty for a multiset
+	keyFields []string
+}
+
+// sliceOrder returns the order of a slice field, or nil if its elements are ordered.
+func (this *DeepEqual) sliceOrder(structType reflect.Type, field reflect.StructField) *sliceOrder {
+	if this.orderPolicy == nil || elemType(field.Type).Kind() != reflect.Slice {
+		return nil
+	}
+	unordered, keyFields := this.orderPolicy.Unordered(structType, field)
+	if !unordered {
+		return nil
+	}
+	return &sliceOrder{keyFields: keyFields}
+}
+
+// unorderedComp compares two slices regardless of the order of their elements.
+// Elements are paired by key, or else by equality, and each pair is compared.
+// Unpaired elements are reported at their index, with a nil value on the other side.
+func (this *DeepEqual) unorderedComp(aSideValue, zSideValue reflect.Value, order *sliceOrder, state *equalState) bool {
+	pairs := this.pairElems(aSideValue, zSideValue, order, state)
+	result := aSideValue.Len() == zSideValue.Len()
+	paired := make([]bool, zSideValue.Len())
+	ignore := state.ignore
+	for i := 0; i < aSideValue.Len(); i++ {
+		elemIgnore, ignored := ignoreElem(ignore, i)
+		if pairs[i] != -1 {
+			paired[pairs[i]] = true
+		}
+		if ignored {
+			continue
+		}
+		zSideCel := reflect.Value{}
+		if pairs[i] != -1 {
+			zSideCel = zSideValue.Index(pairs[i])
+		}
+		state.pushIndex(i)
+		state.ignore = elemIgnore
+		eq := this.equal(aSideValue.Index(i), zSideCel, state)
+		state.ignore = ignore
+		state.pop()
+		if !eq {
+			if state.done() {
+				return false
+			}
+			result = false
+		}
+	}
+	for i := 0; i < zSideValue.Len() && state.report != nil; i++ {
+		if paired[i] {
+			continue
+		}
+		state.pushIndex(i)
+		this.equal(reflect.Value{}, zSideValue.Index(i), state)
+		state.pop()
+		if state.done() {
+			return false
+		}
+	}
+	return result
+}
+
+// pairElems returns, for each a side element, the index of its z side element, or -1.
+// Keyed elements are paired by key. Other elements are paired by equality with a
+// maximum matching, so a tolerance that makes an element equal to several others
+// does not leave elements unpaired that could all have been paired.
+func (this *DeepEqual) pairElems(aSideValue, zSideValue reflect.Value, order *sliceOrder, state *equalState) []int {
+	match := &elemMatch{
+		deepEqual: this,
+		aSide:     aSideValue,
+		zSide:     zSideValue,
+		state:     state,
+		pairs:     make([]int, aSideValue.Len()),
+		owners:    make([]int, zSideValue.Len()),
+		byKey:     make([]bool, zSideValue.Len()),
+		equals:    make(map[[2]int]bool),
+	}
+	for i := range match.owners {
+		match.owners[i] = -1
+	}
+	var byKey map[string][]int
+	if len(order.keyFields) > 0 {
+		byKey = make(map[string][]int)
+		for i := 0; i < zSideValue.Len(); i++ {
+			key, ok := elemKey(zSideValue.Index(i), order.keyFields)
+			if ok {
+				byKey[key] = append(byKey[key], i)
+			}
+		}
+	}
+	unkeyed := make([]int, 0)
+	for i := 0; i < aSideValue.Len(); i++ {
+		match.pairs[i] = -1
+		if byKey != nil {
+			key, ok := elemKey(aSideValue.Index(i), order.keyFields)
+			if ok {
+				indexes := byKey[key]
+				if len(indexes) > 0 {
+					match.pairs[i] = indexes[0]
+					match.byKey[indexes[0]] = true
+					byKey[key] = indexes[1:]
+				}
+				continue
+			}
+		}
+		unkeyed = append(unkeyed, i)
+	}
+	for _, i := range unkeyed {
+		match.augment(i, make([]bool, zSideValue.Len()))
+	}
+	return match.pairs
+}
+
+// elemMatch pairs the elements of two unordered slices by equality.
+type elemMatch struct {
+	deepEqual *DeepEqual
+	aSide     reflect.Value
+	zSide     reflect.Value
+	state     *equalState
+	// pairs is the z side index of each a side element, or -1
+	pairs []int
+	// owners is the a side index of each z side element, or -1
+	owners []int
+	// byKey marks the z side elements already paired by key
+	byKey []bool
+	// equals caches the tentative comparisons of a side and z side elements
+	equals map[[2]int]bool
+}
+
+// augment pairs the a side element with an unpaired equal z side element, trying the
+// same index first. If all its equal elements are taken, an element is taken over from
+// an a side element that can be paired with another one instead.
+func (this *elemMatch) augment(index int, seen []bool) bool {
+	for k := -1; k < len(this.owners); k++ {
+		i := k
+		if k == -1 {
+			i = index
+		} else if k == index {
+			continue
+		}
+		if i >= len(this.owners) || seen[i] || this.byKey[i] || !this.equal(index, i) {
+			continue
+		}
+		seen[i] = true
+		if this.owners[i] == -1 || this.augment(this.owners[i], seen) {
+			this.owners[i] = index
+			this.pairs[index] = i
+			return true
+		}
+	}
+	return false
+}
+
+// equal compares an a side and a z side element. The tentative comparisons neither
+// report differences nor mark visited references of the comparison in progress.
+func (this *elemMatch) equal(aIndex, zIndex int) bool {
+	key := [2]int{aIndex, zIndex}
+	eq, ok := this.equals[key]
+	if !ok {
+		elemIgnore, _ := ignoreElem(this.state.ignore, aIndex)
+		eq = this.deepEqual.equal(this.aSide.Index(aIndex), this.zSide.Index(zIndex), this.state.tentative(aIndex, elemIgnore))
+		this.equals[key] = eq
+	}
+	return eq
+}
+
+// elemKey returns the key of a struct element from its key fields.
+// Returns false for nil elements and elements that are not structs.
+func elemKey(value reflect.Value, keyFields []string) (string, bool) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return "", false
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return "", false
+	}
+	keys := make([]string, len(keyFields))
+	for i, keyField := range keyFields {
+		field := value.FieldByName(keyField)
+		if !field.IsValid() || !field.CanInterface() {
+			return "", false
+		}
+		keys[i] = helping.KeyString(field.Interface())
+	}
+	return helping.JoinStructKey(keys), true
+}
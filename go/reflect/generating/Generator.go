@@ -0,0 +1,643 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package generating provides the generation of random instances of introspected types,
+// e.g. for property-based tests.
+//
+// Key features:
+//   - Fields taken from the Introspector's node tree, skipped fields are left zero
+//   - Leaves populated by kind, enums with their named values
+//   - Maps keyed by their KeyTypeName, slices with bounded lengths
+//   - Primary and unique keys unique within each collection
+//   - Values and lengths within the bounds of the range and length decorators
+//   - Reproducible instances for a given seed
+package generating
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/saichler/l8reflect/go/reflect/helping"
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8types/go/types/l8reflect"
+)
+
+const (
+	// letters are the characters of the generated strings
+	letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	// maxEnumValue is the highest value probed for the named values of an enum
+	maxEnumValue = 256
+	// keyAttempts is the number of attempts to generate an element with a new key
+	keyAttempts = 10
+)
+
+// GenerateOptions bounds the generated instances. Zero fields use their defaults.
+type GenerateOptions struct {
+	// Seed seeds the random source, the same seed generates the same instance
+	Seed int64
+	// MinLength is the minimal length of slices and maps, default 1
+	MinLength int
+	// MaxLength is the maximal length of slices and maps, default 3
+	MaxLength int
+	// StringLength is the length of the generated strings, default 8
+	StringLength int
+	// MaxDepth is the number of nested struct levels populated, default 5.
+	// Deeper structs are left zero, which bounds recursive types.
+	MaxDepth int
+}
+
+// Generator generates random instances of the types known to an Introspector.
+type Generator struct {
+	// resources provides the registry and Introspector nodes of the generated types
+	resources ifs.IResources
+	// generators maps each reflect.Kind to its corresponding generation function
+	generators map[reflect.Kind]func(reflect.Type, *l8reflect.L8Node, *generateState) reflect.Value
+	// enums caches the named values of enum types
+	enums map[reflect.Type][]int64
+	mutex *sync.Mutex
+}
+
+// generateState holds the state of a single generation.
+type generateState struct {
+	random  *rand.Rand
+	options GenerateOptions
+	depth   int
+	// valueRange bounds the numbers of the field being generated, nil if unbounded
+	valueRange *bounds
+	// lengthRange bounds the length of the field being generated, nil if unbounded
+	lengthRange *bounds
+}
+
+// bounds is the inclusive range of a range or length decorator.
+type bounds struct {
+	min float64
+	max float64
+}
+
+// stringerType is the reflect.Type of the fmt.Stringer interface.
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// NewGenerator creates a new Generator of the types registered in the resources.
+func NewGenerator(resources ifs.IResources) *Generator {
+	generator := &Generator{}
+	generator.resources = resources
+	generator.enums = make(map[reflect.Type][]int64)
+	generator.mutex = &sync.Mutex{}
+	generator.initGenerators()
+	return generator
+}
+
+// initGenerators initializes the generation function registry with handlers for all
+// generated kinds. Other kinds, e.g. interfaces and funcs, are left zero.
+func (this *Generator) initGenerators() {
+	this.generators = make(map[reflect.Kind]func(reflect.Type, *l8reflect.L8Node, *generateState) reflect.Value)
+	for _, kind := range []reflect.Kind{reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64} {
+		this.generators[kind] = this.intGenerator
+	}
+	for _, kind := range []reflect.Kind{reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64} {
+		this.generators[kind] = this.uintGenerator
+	}
+	this.generators[reflect.Float32] = this.floatGenerator
+	this.generators[reflect.Float64] = this.floatGenerator
+	this.generators[reflect.Complex64] = this.complexGenerator
+	this.generators[reflect.Complex128] = this.complexGenerator
+	this.generators[reflect.Bool] = this.boolGenerator
+	this.generators[reflect.String] = this.stringGenerator
+	this.generators[reflect.Ptr] = this.ptrGenerator
+	this.generators[reflect.Struct] = this.structGenerator
+	this.generators[reflect.Slice] = this.sliceGenerator
+	this.generators[reflect.Array] = this.arrayGenerator
+	this.generators[reflect.Map] = this.mapGenerator
+}
+
+// Generate returns a pointer to a random instance of the registered type, populating the
+// fields of its node tree. Fields with a range decorator, see AddRangeDecorator, get numbers
+// within its range, and fields with a length decorator, see AddLengthDecorator, get strings,
+// slices and maps of a length within its range, overriding the options. Elements of slices and maps of a type with a primary key
+// decorator, or else a unique key decorator, have distinct keys, and maps keyed by the
+// single primary key field of their elements use it as their keys.
+// Returns an error if the type is not registered or not a struct.
+func (this *Generator) Generate(typeName string, options *GenerateOptions) (interface{}, error) {
+	info, err := this.resources.Registry().Info(typeName)
+	if err != nil {
+		return nil, err
+	}
+	typ := info.Type()
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, errors.New("Cannot generate " + typeName + ", it is not a struct")
+	}
+	state := &generateState{}
+	if options != nil {
+		state.options = *options
+	}
+	state.options.defaults()
+	state.random = rand.New(rand.NewSource(state.options.Seed))
+	instance := reflect.New(typ)
+	instance.Elem().Set(this.generate(typ, nil, state))
+	return instance.Interface(), nil
+}
+
+// defaults sets the zero options to their defaults.
+func (this *GenerateOptions) defaults() {
+	if this.MinLength <= 0 {
+		this.MinLength = 1
+	}
+	if this.MaxLength < this.MinLength {
+		this.MaxLength = this.MinLength + 2
+	}
+	if this.StringLength <= 0 {
+		this.StringLength = 8
+	}
+	if this.MaxDepth <= 0 {
+		this.MaxDepth = 5
+	}
+}
+
+// generate dispatches to the generator of the type's kind.
+func (this *Generator) generate(typ reflect.Type, node *l8reflect.L8Node, state *generateState) reflect.Value {
+	generator := this.generators[typ.Kind()]
+	if generator == nil {
+		return reflect.Zero(typ)
+	}
+	return generator(typ, node, state)
+}
+
+// structGenerator populates the fields of the struct's node, up to the maximal depth.
+func (this *Generator) structGenerator(typ reflect.Type, node *l8reflect.L8Node, state *generateState) reflect.Value {
+	value := reflect.New(typ).Elem()
+	if state.depth >= state.options.MaxDepth {
+		return value
+	}
+	node, ok := this.node(typ)
+	if !ok {
+		return value
+	}
+	valueRange, lengthRange := state.valueRange, state.lengthRange
+	state.depth++
+	defer func() {
+		state.depth--
+		state.valueRange, state.lengthRange = valueRange, lengthRange
+	}()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		attr, ok := node.Attributes[field.Name]
+		if !ok {
+			continue
+		}
+		state.valueRange = fieldBounds(node, helping.DecoratorType_Range, field.Name)
+		state.lengthRange = fieldBounds(node, helping.DecoratorType_Length, field.Name)
+		value.Field(i).Set(this.generate(field.Type, attr, state))
+	}
+	return value
+}
+
+// ptrGenerator generates the value a pointer points to.
+func (this *Generator) ptrGenerator(typ reflect.Type, node *l8reflect.L8Node, state *generateState) reflect.Value {
+	if state.tooDeep(typ.Elem()) {
+		return reflect.Zero(typ)
+	}
+	newPtr := reflect.New(typ.Elem())
+	newPtr.Elem().Set(this.generate(typ.Elem(), node, state))
+	return newPtr.Convert(typ)
+}
+
+// sliceGenerator generates a slice of a random bounded length, whose elements have
+// distinct keys. []byte slices are random bytes of the string length.
+func (this *Generator) sliceGenerator(typ reflect.Type, node *l8reflect.L8Node, state *generateState) reflect.Value {
+	if typ.Elem().Kind() == reflect.Uint8 {
+		bytes := make([]byte, state.stringLength())
+		state.random.Read(bytes)
+		value := reflect.ValueOf(bytes).Convert(typ)
+		if state.valueRange != nil {
+			for i := range bytes {
+				value.Index(i).Set(this.generate(typ.Elem(), node, state))
+			}
+		}
+		return value
+	}
+	if state.tooDeep(typ.Elem()) {
+		return reflect.Zero(typ)
+	}
+	length := state.length()
+	defer state.elements()()
+	value := reflect.MakeSlice(typ, 0, length)
+	keyFields := this.keyFields(typ.Elem())
+	keys := make(map[string]bool)
+	for i := 0; i < length; i++ {
+		elem, key, ok := this.uniqueElem(typ.Elem(), node, keyFields, keys, state)
+		if !ok {
+			break
+		}
+		keys[key] = true
+		value = reflect.Append(value, elem)
+	}
+	return value
+}
+
+// arrayGenerator populates every element of an array.
+func (this *Generator) arrayGenerator(typ reflect.Type, node *l8reflect.L8Node, state *generateState) reflect.Value {
+	value := reflect.New(typ).Elem()
+	defer state.elements()()
+	for i := 0; i < value.Len(); i++ {
+		value.Index(i).Set(this.generate(typ.Elem(), node, state))
+	}
+	return value
+}
+
+// mapGenerator generates a map of a random bounded length, with keys of the node's
+// KeyTypeName. Elements with a single primary key field of the key type are keyed by it.
+func (this *Generator) mapGenerator(typ reflect.Type, node *l8reflect.L8Node, state *generateState) reflect.Value {
+	if state.tooDeep(typ.Elem()) {
+		return reflect.Zero(typ)
+	}
+	keyType := this.keyType(typ, node)
+	length := state.length()
+	defer state.elements()()
+	value := reflect.MakeMapWithSize(typ, length)
+	keyFields := this.keyFields(typ.Elem())
+	keys := make(map[string]bool)
+	for i := 0; i < length; i++ {
+		elem, key, ok := this.uniqueElem(typ.Elem(), node, keyFields, keys, state)
+		if !ok {
+			break
+		}
+		keys[key] = true
+		mapKey, ok := this.mapKey(value, elem, keyFields, keyType, state)
+		if !ok {
+			break
+		}
+		value.SetMapIndex(mapKey, elem)
+	}
+	return value
+}
+
+// mapKey returns a key of the map for the element, its primary key if it has a single
+// primary key field of the key type. Returns false if no key missing in the map was found.
+func (this *Generator) mapKey(value, elem reflect.Value, keyFields []string, keyType reflect.Type, state *generateState) (reflect.Value, bool) {
+	mapKey, ok := primaryKey(elem, keyFields, keyType)
+	if ok {
+		mapKey = mapKey.Convert(value.Type().Key())
+		return mapKey, !value.MapIndex(mapKey).IsValid()
+	}
+	// the range bounds the values of the map, not its keys
+	valueRange := state.valueRange
+	state.valueRange = nil
+	defer func() { state.valueRange = valueRange }()
+	for attempt := 0; attempt < keyAttempts; attempt++ {
+		mapKey = this.generate(keyType, nil, state).Convert(value.Type().Key())
+		if !value.MapIndex(mapKey).IsValid() {
+			return mapKey, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// uniqueElem generates an element whose key is not in keys, returning it with its key.
+// Elements without key fields are always unique. Returns false if no new key was generated.
+func (this *Generator) uniqueElem(typ reflect.Type, node *l8reflect.L8Node, keyFields []string, keys map[string]bool, state *generateState) (reflect.Value, string, bool) {
+	for attempt := 0; attempt < keyAttempts; attempt++ {
+		elem := this.generate(typ, node, state)
+		if len(keyFields) == 0 {
+			return elem, "", true
+		}
+		key, ok := elemKey(elem, keyFields)
+		if !ok {
+			return elem, "", true
+		}
+		if !keys[key] {
+			return elem, key, true
+		}
+	}
+	return reflect.Value{}, "", false
+}
+
+// intGenerator generates a non negative signed integer, or a named value of an enum,
+// within the value range if any. An enum whose named values are all out of the range
+// gets the named value nearest to it.
+func (this *Generator) intGenerator(typ reflect.Type, node *l8reflect.L8Node, state *generateState) reflect.Value {
+	value := reflect.New(typ).Elem()
+	enumValues := this.enumValues(typ)
+	inRange := state.inRange(enumValues)
+	if len(inRange) > 0 {
+		value.SetInt(inRange[state.random.Intn(len(inRange))])
+		return value
+	}
+	if len(enumValues) > 0 {
+		value.SetInt(state.nearest(enumValues))
+		return value
+	}
+	if state.valueRange != nil {
+		value.SetInt(state.intInRange(-1<<(typ.Bits()-1), 1<<(typ.Bits()-1)-1))
+		return value
+	}
+	value.SetInt(state.nonNegative(typ.Bits()))
+	return value
+}
+
+// uintGenerator generates an unsigned integer, within the value range if any.
+func (this *Generator) uintGenerator(typ reflect.Type, node *l8reflect.L8Node, state *generateState) reflect.Value {
+	value := reflect.New(typ).Elem()
+	if state.valueRange != nil {
+		value.SetUint(state.uintInRange(math.MaxUint64 >> (64 - typ.Bits())))
+		return value
+	}
+	value.SetUint(uint64(state.nonNegative(typ.Bits())))
+	return value
+}
+
+// floatGenerator generates a float in [0, 1000), or within the value range if any.
+func (this *Generator) floatGenerator(typ reflect.Type, node *l8reflect.L8Node, state *generateState) reflect.Value {
+	value := reflect.New(typ).Elem()
+	if state.valueRange != nil {
+		value.SetFloat(state.valueRange.min + state.random.Float64()*(state.valueRange.max-state.valueRange.min))
+		return value
+	}
+	value.SetFloat(state.random.Float64() * 1000)
+	return value
+}
+
+// complexGenerator generates a complex with parts in [0, 1000).
+func (this *Generator) complexGenerator(typ reflect.Type, node *l8reflect.L8Node, state *generateState) reflect.Value {
+	value := reflect.New(typ).Elem()
+	value.SetComplex(complex(state.random.Float64()*1000, state.random.Float64()*1000))
+	return value
+}
+
+// boolGenerator generates a bool.
+func (this *Generator) boolGenerator(typ reflect.Type, node *l8reflect.L8Node, state *generateState) reflect.Value {
+	value := reflect.New(typ).Elem()
+	value.SetBool(state.random.Intn(2) == 1)
+	return value
+}
+
+// stringGenerator generates an alphanumeric string of the string length.
+func (this *Generator) stringGenerator(typ reflect.Type, node *l8reflect.L8Node, state *generateState) reflect.Value {
+	buff := strings.Builder{}
+	length := state.stringLength()
+	for i := 0; i < length; i++ {
+		buff.WriteByte(letters[state.random.Intn(len(letters))])
+	}
+	value := reflect.New(typ).Elem()
+	value.SetString(buff.String())
+	return value
+}
+
+// length returns a random length of a slice or map within the length range, or else
+// within the bounds of the options.
+func (this *generateState) length() int {
+	if this.lengthRange != nil {
+		return this.lengthInRange()
+	}
+	return this.options.MinLength + this.random.Intn(this.options.MaxLength-this.options.MinLength+1)
+}
+
+// stringLength returns a random length of a string within the length range, or else
+// the string length of the options.
+func (this *generateState) stringLength() int {
+	if this.lengthRange != nil {
+		return this.lengthInRange()
+	}
+	return this.options.StringLength
+}
+
+// lengthInRange returns a random length within the length range.
+func (this *generateState) lengthInRange() int {
+	min := int(math.Ceil(this.lengthRange.min))
+	max := int(math.Floor(this.lengthRange.max))
+	if max <= min {
+		return min
+	}
+	return min + this.random.Intn(max-min+1)
+}
+
+// elements clears the length range while generating the elements of a slice, array or
+// map, as it bounds the collection, returning the function restoring it.
+func (this *generateState) elements() func() {
+	lengthRange := this.lengthRange
+	this.lengthRange = nil
+	return func() { this.lengthRange = lengthRange }
+}
+
+// inRange returns the values within the value range, all of them if there is none.
+func (this *generateState) inRange(values []int64) []int64 {
+	if this.valueRange == nil {
+		return values
+	}
+	inRange := make([]int64, 0, len(values))
+	for _, value := range values {
+		if float64(value) >= this.valueRange.min && float64(value) <= this.valueRange.max {
+			inRange = append(inRange, value)
+		}
+	}
+	return inRange
+}
+
+// nearest returns the value nearest to the value range, the lowest one on a tie.
+func (this *generateState) nearest(values []int64) int64 {
+	nearest, distance := values[0], math.Inf(1)
+	for _, value := range values {
+		d := math.Max(this.valueRange.min-float64(value), float64(value)-this.valueRange.max)
+		if d < distance {
+			nearest, distance = value, d
+		}
+	}
+	return nearest
+}
+
+// intInRange returns a random integer within the value range, clamped to [low, high].
+func (this *generateState) intInRange(low, high int64) int64 {
+	if min := math.Ceil(this.valueRange.min); min >= float64(high) {
+		low = high
+	} else if min > float64(low) {
+		low = int64(min)
+	}
+	if max := math.Floor(this.valueRange.max); max <= float64(low) {
+		high = low
+	} else if max < float64(high) {
+		high = int64(max)
+	}
+	span := uint64(high - low)
+	if span == math.MaxUint64 {
+		return int64(this.random.Uint64())
+	}
+	return low + int64(this.random.Uint64()%(span+1))
+}
+
+// uintInRange returns a random unsigned integer within the value range, clamped to [0, high].
+func (this *generateState) uintInRange(high uint64) uint64 {
+	low := uint64(0)
+	if min := math.Ceil(this.valueRange.min); min >= float64(high) {
+		low = high
+	} else if min > 0 {
+		low = uint64(min)
+	}
+	if max := math.Floor(this.valueRange.max); max <= float64(low) {
+		high = low
+	} else if max < float64(high) {
+		high = uint64(max)
+	}
+	span := high - low
+	if span == math.MaxUint64 {
+		return this.random.Uint64()
+	}
+	return low + this.random.Uint64()%(span+1)
+}
+
+// fieldBounds returns the bounds of a field in a range or length decorator of a node,
+// nil if it has none.
+func fieldBounds(node *l8reflect.L8Node, decoratorType l8reflect.L8DecoratorType, fieldName string) *bounds {
+	min, max, ok := helping.FieldBounds(node, decoratorType, fieldName)
+	if !ok {
+		return nil
+	}
+	return &bounds{min: min, max: max}
+}
+
+// nonNegative returns a random non negative integer that fits a signed integer of the
+// given bits.
+func (this *generateState) nonNegative(bits int) int64 {
+	if bits >= 64 {
+		return this.random.Int63()
+	}
+	return this.random.Int63n(int64(1) << (bits - 1))
+}
+
+// tooDeep returns true if the type is a struct, or a pointer to one, beyond the maximal
+// depth. Pointers, slices and maps of such types are left nil.
+func (this *generateState) tooDeep(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Struct && this.depth >= this.options.MaxDepth
+}
+
+// node returns the root node of a struct type, which holds the type's decorators,
+// inspecting the type if needed.
+func (this *Generator) node(typ reflect.Type) (*l8reflect.L8Node, bool) {
+	introspector := this.resources.Introspector()
+	// nested nodes of the same type are copies that may lack the decorators
+	node, ok := introspector.Node(helping.CanonicalTypeName(typ.Name()))
+	if ok {
+		return node, true
+	}
+	node, err := introspector.Inspect(reflect.New(typ).Interface())
+	return node, err == nil
+}
+
+// keyFields returns the primary key fields of a struct element type, or else its unique
+// key fields, nil if it has none.
+func (this *Generator) keyFields(typ reflect.Type) []string {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+	node, ok := this.node(typ)
+	if !ok {
+		return nil
+	}
+	keyFields := helping.DecoratorFields(node, l8reflect.L8DecoratorType_Primary)
+	if len(keyFields) == 0 {
+		keyFields = helping.DecoratorFields(node, l8reflect.L8DecoratorType_Unique)
+	}
+	return keyFields
+}
+
+// keyType returns the registered type of the node's KeyTypeName, or the map's key type.
+func (this *Generator) keyType(typ reflect.Type, node *l8reflect.L8Node) reflect.Type {
+	if node != nil && node.KeyTypeName != "" {
+		info, err := this.resources.Registry().Info(node.KeyTypeName)
+		if err == nil && info.Type().ConvertibleTo(typ.Key()) {
+			return info.Type()
+		}
+	}
+	return typ.Key()
+}
+
+// enumValues returns the named values of an enum type, i.e. the values its String method
+// does not render as a number, nil if the type is not an enum.
+func (this *Generator) enumValues(typ reflect.Type) []int64 {
+	if typ.Kind() != reflect.Int32 || !typ.Implements(stringerType) {
+		return nil
+	}
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	values, ok := this.enums[typ]
+	if ok {
+		return values
+	}
+	value := reflect.New(typ).Elem()
+	for i := int64(0); i < maxEnumValue; i++ {
+		value.SetInt(i)
+		name := value.Interface().(fmt.Stringer).String()
+		if name != "" && name != strconv.FormatInt(i, 10) {
+			values = append(values, i)
+		}
+	}
+	this.enums[typ] = values
+	return values
+}
+
+// elemKey returns the key of a struct element from its key fields.
+// Returns false for nil elements and elements that are not structs.
+func elemKey(elem reflect.Value, keyFields []string) (string, bool) {
+	for elem.Kind() == reflect.Ptr {
+		if elem.IsNil() {
+			return "", false
+		}
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return "", false
+	}
+	parts := make([]string, len(keyFields))
+	for i, keyField := range keyFields {
+		field := elem.FieldByName(keyField)
+		if !field.IsValid() {
+			return "", false
+		}
+		parts[i] = helping.KeyString(field.Interface())
+	}
+	return helping.JoinStructKey(parts), true
+}
+
+// primaryKey returns the single key field of an element if it is of the key type.
+func primaryKey(elem reflect.Value, keyFields []string, keyType reflect.Type) (reflect.Value, bool) {
+	if len(keyFields) != 1 {
+		return reflect.Value{}, false
+	}
+	for elem.Kind() == reflect.Ptr {
+		if elem.IsNil() {
+			return reflect.Value{}, false
+		}
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	field := elem.FieldByName(keyFields[0])
+	if !field.IsValid() || !field.Type().ConvertibleTo(keyType) {
+		return reflect.Value{}, false
+	}
+	return field.Convert(keyType), true
+}
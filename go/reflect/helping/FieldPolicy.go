@@ -0,0 +1,121 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the field-skip policy shared by cloning, equality,
+// introspection and updating, so excluded fields are handled consistently.
+
+package helping
+
+import (
+	"path"
+	"reflect"
+	"strings"
+)
+
+// FieldPolicy decides which struct fields are skipped when cloning, comparing,
+// introspecting and updating.
+type FieldPolicy interface {
+	// SkipField returns true if the field of the given struct type should be skipped.
+	SkipField(structType reflect.Type, field reflect.StructField) bool
+}
+
+// FieldPolicyFunc adapts a function to the FieldPolicy interface.
+type FieldPolicyFunc func(structType reflect.Type, field reflect.StructField) bool
+
+// SkipField calls the function.
+func (this FieldPolicyFunc) SkipField(structType reflect.Type, field reflect.StructField) bool {
+	return this(structType, field)
+}
+
+// DefaultFieldPolicy skips the fields matched by IgnoreName
+// (DoNotCompare, DoNotCopy, XXX prefix and unexported fields).
+var DefaultFieldPolicy FieldPolicy = FieldPolicyFunc(func(structType reflect.Type, field reflect.StructField) bool {
+	return IgnoreName(field.Name)
+})
+
+// NewFieldPolicy combines the DefaultFieldPolicy with the given policies.
+// A field is skipped if any of the policies skips it. The default rule is
+// always kept, as unexported and generated fields can never be copied or compared.
+func NewFieldPolicy(policies ...FieldPolicy) FieldPolicy {
+	if len(policies) == 0 {
+		return DefaultFieldPolicy
+	}
+	all := make(compositePolicy, 0, len(policies)+1)
+	all = append(all, DefaultFieldPolicy)
+	for _, policy := range policies {
+		if policy != nil {
+			all = append(all, policy)
+		}
+	}
+	return all
+}
+
+// compositePolicy skips a field if any of its policies skips it.
+type compositePolicy []FieldPolicy
+
+func (this compositePolicy) SkipField(structType reflect.Type, field reflect.StructField) bool {
+	for _, policy := range this {
+		if policy.SkipField(structType, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewNamePolicy skips fields whose name matches any of the given patterns.
+// Patterns use path.Match syntax, e.g. "Cache*" or "*Transient".
+func NewNamePolicy(patterns ...string) FieldPolicy {
+	return FieldPolicyFunc(func(structType reflect.Type, field reflect.StructField) bool {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, field.Name); ok {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// NewTagPolicy skips fields that have the given struct tag key.
+// If values are provided, the field is skipped only if one of the comma separated
+// tag values matches, e.g. NewTagPolicy("l8", "transient") matches `l8:"transient"`.
+func NewTagPolicy(key string, values ...string) FieldPolicy {
+	return FieldPolicyFunc(func(structType reflect.Type, field reflect.StructField) bool {
+		tag, ok := field.Tag.Lookup(key)
+		if !ok {
+			return false
+		}
+		if len(values) == 0 {
+			return true
+		}
+		for _, tagValue := range strings.Split(tag, ",") {
+			for _, value := range values {
+				if strings.TrimSpace(tagValue) == value {
+					return true
+				}
+			}
+		}
+		return false
+	})
+}
+
+// NewTypePolicy skips fields of any of the given types, e.g. sync.Mutex or *regexp.Regexp.
+func NewTypePolicy(types ...reflect.Type) FieldPolicy {
+	return FieldPolicyFunc(func(structType reflect.Type, field reflect.StructField) bool {
+		for _, typ := range types {
+			if field.Type == typ {
+				return true
+			}
+		}
+		return false
+	})
+}
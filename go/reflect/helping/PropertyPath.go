@@ -0,0 +1,90 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the parsing of the property path grammar, e.g.
+// "person.addresses<{24}home>.street", shared by the properties and cloning packages.
+
+package helping
+
+import (
+	"errors"
+	"strings"
+)
+
+// PathSegment is an attribute of a property path with its optional key.
+type PathSegment struct {
+	// Name is the attribute name as written in the path
+	Name string
+	// Key is the encoded key between the angle brackets, without them
+	Key string
+	// HasKey is true if the attribute has a key, which may be empty ("<>")
+	HasKey bool
+}
+
+// String returns the segment as written in a property path.
+func (this PathSegment) String() string {
+	if !this.HasKey {
+		return this.Name
+	}
+	return this.Name + "<" + this.Key + ">"
+}
+
+// PathSegments splits a property path into its attributes and their keys.
+// Dots inside keys (e.g. "<{14}1.5>") do not split segments.
+// Returns an error if a key is not closed or an attribute is empty.
+func PathSegments(propertyId string) ([]PathSegment, error) {
+	segments := make([]PathSegment, 0)
+	segment := PathSegment{}
+	name := strings.Builder{}
+	key := strings.Builder{}
+	open := false
+	for _, c := range propertyId {
+		switch {
+		case c == '<' && !open:
+			open = true
+			segment.HasKey = true
+		case c == '>' && open:
+			open = false
+		case open:
+			key.WriteRune(c)
+		case c == '.':
+			segment.Name, segment.Key = name.String(), key.String()
+			segments = append(segments, segment)
+			segment = PathSegment{}
+			name.Reset()
+			key.Reset()
+		default:
+			name.WriteRune(c)
+		}
+	}
+	if open {
+		return nil, errors.New("Unclosed key in path " + propertyId)
+	}
+	segment.Name, segment.Key = name.String(), key.String()
+	segments = append(segments, segment)
+	for _, segment := range segments {
+		if segment.Name == "" {
+			return nil, errors.New("Empty attribute in path " + propertyId)
+		}
+	}
+	return segments, nil
+}
+
+// JoinPathSegments returns the property path of the segments.
+func JoinPathSegments(segments []PathSegment) string {
+	parts := make([]string, len(segments))
+	for i, segment := range segments {
+		parts[i] = segment.String()
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,55 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the reference policy used by cloning to treat fields
+// as links to other objects, e.g. the z-side of a many to many relation,
+// instead of owned subtrees.
+
+package helping
+
+import (
+	"reflect"
+)
+
+// ReferencePolicy decides which struct fields are references to other objects.
+// Reference fields are not deep cloned.
+type ReferencePolicy interface {
+	// Reference returns true if the field of the given struct type is a reference.
+	// keyFields lists the fields identifying the referenced objects. When it is empty
+	// the reference is copied as is (shallow), otherwise the referenced objects are
+	// cloned with only their key fields populated.
+	Reference(structType reflect.Type, field reflect.StructField) (isReference bool, keyFields []string)
+}
+
+// ReferencePolicyFunc adapts a function to the ReferencePolicy interface.
+type ReferencePolicyFunc func(structType reflect.Type, field reflect.StructField) (bool, []string)
+
+// Reference calls the function.
+func (this ReferencePolicyFunc) Reference(structType reflect.Type, field reflect.StructField) (bool, []string) {
+	return this(structType, field)
+}
+
+// ElemStructType returns the struct type held by a field type, dereferencing pointers
+// and slice, array and map elements, e.g. *Port for []*Port. Returns nil if there is none.
+func ElemStructType(typ reflect.Type) reflect.Type {
+	for {
+		switch typ.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			typ = typ.Elem()
+		case reflect.Struct:
+			return typ
+		default:
+			return nil
+		}
+	}
+}
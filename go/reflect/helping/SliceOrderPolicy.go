@@ -0,0 +1,37 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the slice order policy used by DeepEqual to compare slices
+// that are logically sets or keyed lists regardless of the order of their elements.
+
+package helping
+
+import (
+	"reflect"
+)
+
+// SliceOrderPolicy decides which slice fields are compared regardless of the order of their elements.
+type SliceOrderPolicy interface {
+	// Unordered returns true if the slice field of the given struct type is unordered.
+	// keyFields lists the fields identifying the struct elements. When it is set, elements
+	// are matched by key before being compared, otherwise the slices are compared as multisets.
+	Unordered(structType reflect.Type, field reflect.StructField) (unordered bool, keyFields []string)
+}
+
+// SliceOrderPolicyFunc adapts a function to the SliceOrderPolicy interface.
+type SliceOrderPolicyFunc func(structType reflect.Type, field reflect.StructField) (bool, []string)
+
+// Unordered calls the function.
+func (this SliceOrderPolicyFunc) Unordered(structType reflect.Type, field reflect.StructField) (bool, []string) {
+	return this(structType, field)
+}
@@ -0,0 +1,125 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the decorator types used by this library in addition to the ones
+// defined in l8reflect. They share the L8Node.Decorators map, so their values start
+// well above the l8reflect enum to avoid collisions.
+
+package helping
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/saichler/l8types/go/types/l8reflect"
+)
+
+const (
+	// DecoratorType_Skip lists the fields of a type to skip when cloning, comparing and updating.
+	DecoratorType_Skip l8reflect.L8DecoratorType = 1000 + iota
+	// DecoratorType_Reference lists the fields of a type that reference other objects,
+	// so cloning copies the reference instead of the referenced objects.
+	DecoratorType_Reference
+	// DecoratorType_ReferenceKey lists the fields of a type that reference other objects,
+	// so cloning copies only the primary key of the referenced objects.
+	DecoratorType_ReferenceKey
+	// DecoratorType_Sensitive lists the fields of a type holding secrets,
+	// redacted by a Cloner with a redact policy.
+	DecoratorType_Sensitive
+	// DecoratorType_Set lists the slice fields of a type that are logically sets,
+	// so their elements order is ignored when comparing.
+	DecoratorType_Set
+	// DecoratorType_Alias lists "Field=Alias" entries, the names a field of a type
+	// is known by in other types, so mapping between the types matches them.
+	DecoratorType_Alias
+	// DecoratorType_Range lists "Field=Min:Max" entries, the inclusive range of the values
+	// of a numeric field, or of the numeric elements of a slice, array or map field.
+	DecoratorType_Range
+	// DecoratorType_Length lists "Field=Min:Max" entries, the inclusive range of the length
+	// of a string, slice or map field.
+	DecoratorType_Length
+)
+
+// AliasSeparator separates a field from its alias in the entries of DecoratorType_Alias,
+// and from its bounds in the entries of DecoratorType_Range and DecoratorType_Length.
+const AliasSeparator = "="
+
+// BoundsSeparator separates the minimum from the maximum in the entries of
+// DecoratorType_Range and DecoratorType_Length.
+const BoundsSeparator = ":"
+
+// DecoratorFields returns the fields of a decorator type on a node, or nil if not set.
+func DecoratorFields(node *l8reflect.L8Node, decoratorType l8reflect.L8DecoratorType) []string {
+	if node == nil || node.Decorators == nil {
+		return nil
+	}
+	decorator := node.Decorators[int32(decoratorType)]
+	if decorator == nil {
+		return nil
+	}
+	return decorator.Fields
+}
+
+// HasDecoratorField returns true if the field is listed by a decorator type on a node.
+func HasDecoratorField(node *l8reflect.L8Node, decoratorType l8reflect.L8DecoratorType, fieldName string) bool {
+	for _, field := range DecoratorFields(node, decoratorType) {
+		if field == fieldName {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldAliases returns the aliases of a field listed by the alias decorator of a node.
+func FieldAliases(node *l8reflect.L8Node, fieldName string) []string {
+	var aliases []string
+	for _, entry := range DecoratorFields(node, DecoratorType_Alias) {
+		field, alias, ok := strings.Cut(entry, AliasSeparator)
+		if ok && field == fieldName {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
+
+// BoundsEntry returns the entry of a field with the given bounds, for DecoratorType_Range
+// and DecoratorType_Length.
+func BoundsEntry(fieldName string, min, max float64) string {
+	return fieldName + AliasSeparator + strconv.FormatFloat(min, 'g', -1, 64) +
+		BoundsSeparator + strconv.FormatFloat(max, 'g', -1, 64)
+}
+
+// FieldBounds returns the bounds of a field listed by a DecoratorType_Range or
+// DecoratorType_Length decorator of a node, false if the field has none.
+func FieldBounds(node *l8reflect.L8Node, decoratorType l8reflect.L8DecoratorType, fieldName string) (float64, float64, bool) {
+	for _, entry := range DecoratorFields(node, decoratorType) {
+		field, bounds, ok := strings.Cut(entry, AliasSeparator)
+		if !ok || field != fieldName {
+			continue
+		}
+		minText, maxText, ok := strings.Cut(bounds, BoundsSeparator)
+		if !ok {
+			return 0, 0, false
+		}
+		min, err := strconv.ParseFloat(minText, 64)
+		if err != nil {
+			return 0, 0, false
+		}
+		max, err := strconv.ParseFloat(maxText, 64)
+		if err != nil {
+			return 0, 0, false
+		}
+		return min, max, true
+	}
+	return 0, 0, false
+}
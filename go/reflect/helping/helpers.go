@@ -115,16 +115,118 @@ func PropertyNodeKey(instanceId string) string {
 	return buff.String()
 }
 
+// StructKeySeparator separates the field values of a struct map key in a property id.
+const StructKeySeparator = "::"
+
+// KeyString encodes a map/slice key for a property id.
+// Struct keys are encoded as their exported field values, each with its type prefix,
+// joined by "::" so they can be decoded back when the property id is parsed.
+func KeyString(key interface{}) string {
+	keyStr := strings2.New()
+	keyStr.TypesPrefix = true
+	value := reflect.ValueOf(key)
+	if value.Kind() != reflect.Struct {
+		return keyStr.StringOf(key)
+	}
+	fields := make([]string, 0, value.NumField())
+	for i := 0; i < value.NumField(); i++ {
+		if IgnoreName(value.Type().Field(i).Name) {
+			continue
+		}
+		fields = append(fields, keyStr.StringOf(value.Field(i).Interface()))
+	}
+	return JoinStructKey(fields)
+}
+
+// JoinStructKey joins the encoded fields of a struct key with "::".
+// A ':' or '\' inside a field is escaped with '\', so fields containing "::"
+// are not split apart by StructKeyFields.
+func JoinStructKey(fields []string) string {
+	buff := strings.Builder{}
+	for i, field := range fields {
+		if i > 0 {
+			buff.WriteString(StructKeySeparator)
+		}
+		for _, c := range field {
+			if c == ':' || c == '\\' {
+				buff.WriteByte('\\')
+			}
+			buff.WriteRune(c)
+		}
+	}
+	return buff.String()
+}
+
+// StructKeyFields splits a struct key written by JoinStructKey back into its fields,
+// removing the escapes.
+func StructKeyFields(key string) []string {
+	fields := make([]string, 0, 2)
+	buff := strings.Builder{}
+	for i := 0; i < len(key); i++ {
+		switch {
+		case key[i] == '\\' && i+1 < len(key):
+			i++
+			buff.WriteByte(key[i])
+		case strings.HasPrefix(key[i:], StructKeySeparator):
+			fields = append(fields, buff.String())
+			buff.Reset()
+			i += len(StructKeySeparator) - 1
+		default:
+			buff.WriteByte(key[i])
+		}
+	}
+	return append(fields, buff.String())
+}
+
+// CanonicalTypeName returns a stable, path-safe name for a type name.
+// Generic instantiations such as "Page[github.com/x/model.Device]" contain brackets,
+// dots and slashes that collide with the property path grammar, so package paths are
+// dropped and the type arguments are joined with "_", e.g. "Page_Device".
+// Non-generic names are returned unchanged, and canonical names map to themselves.
+func CanonicalTypeName(name string) string {
+	if !strings.ContainsAny(name, "[]") {
+		return name
+	}
+	buff := strings2.New()
+	first := true
+	for _, token := range strings.FieldsFunc(name, isTypeNameSeparator) {
+		index := strings.LastIndex(token, ".")
+		if index != -1 {
+			token = token[index+1:]
+		}
+		if token == "" {
+			continue
+		}
+		if !first {
+			buff.Add("_")
+		}
+		buff.Add(token)
+		first = false
+	}
+	return buff.String()
+}
+
+// isTypeNameSeparator returns true for the characters separating the
+// type arguments of a generic type name.
+func isTypeNameSeparator(c rune) bool {
+	switch c {
+	case '[', ']', ',', '*', ' ', '(', ')':
+		return true
+	}
+	return false
+}
+
 // NodeCacheKey generates a unique cache key for an L8Node based on its path.
 // The key is built by traversing from the root to the current node,
 // concatenating lowercase type/field names separated by dots.
+// The root type name is canonicalized so generic types produce path-safe keys.
 // The result is cached in the node for subsequent lookups.
 func NodeCacheKey(node *l8reflect.L8Node) string {
 	if node.CachedKey != "" {
 		return node.CachedKey
 	}
 	if node.Parent == nil {
-		return strings.ToLower(node.TypeName)
+		return strings.ToLower(CanonicalTypeName(node.TypeName))
 	}
 	buff := strings2.New()
 	buff.Add(NodeCacheKey(node.Parent))
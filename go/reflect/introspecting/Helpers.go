@@ -57,7 +57,7 @@ func (this *Introspector) fixClone(clone *l8reflect.L8Node, parent *l8reflect.L8
 // addNode creates a new node for a type or returns a clone of an existing non-leaf node.
 // Returns (node, true) if an existing node was cloned, (node, false) for new nodes.
 func (this *Introspector) addNode(_type reflect.Type, _parent *l8reflect.L8Node, _fieldName string) (*l8reflect.L8Node, bool) {
-	exist, ok := this.typeToNode.Get(_type.Name())
+	exist, ok := this.typeToNode.Get(helping.CanonicalTypeName(_type.Name()))
 	if ok && !helping.IsLeaf(exist) {
 		clone := this.cloner.Clone(exist).(*l8reflect.L8Node)
 		this.fixClone(clone, _parent, _fieldName)
@@ -78,13 +78,13 @@ func (this *Introspector) addNode(_type reflect.Type, _parent *l8reflect.L8Node,
 	}
 	this.pathToNode.Put(nodePath, node)
 	if _type.Kind() == reflect.Struct {
-		this.typeToNode.Put(node.TypeName, node)
+		this.typeToNode.Put(helping.CanonicalTypeName(node.TypeName), node)
 	}
 	return node, false
 }
 
 // inspectStruct recursively inspects a struct type and builds its node tree.
-// Iterates through all exported fields, handling slices, maps, pointers, and primitives.
+// Iterates through all exported fields, handling slices, arrays, maps, pointers, and primitives.
 func (this *Introspector) inspectStruct(_type reflect.Type, _parent *l8reflect.L8Node, _fieldName string) *l8reflect.L8Node {
 	localNode, isClone := this.addNode(_type, _parent, _fieldName)
 	if isClone {
@@ -105,16 +105,18 @@ func (this *Introspector) inspectStruct(_type reflect.Type, _parent *l8reflect.L
 	this.registry.RegisterType(_type)
 	for index := 0; index < _type.NumField(); index++ {
 		field := _type.Field(index)
-		if helping.IgnoreName(field.Name) {
+		if this.fieldPolicy.SkipField(_type, field) {
 			continue
 		}
-		if field.Type.Kind() == reflect.Slice {
+		if field.Type.Kind() == reflect.Slice || field.Type.Kind() == reflect.Array {
 			this.inspectSlice(field.Type, localNode, field.Name)
 		} else if field.Type.Kind() == reflect.Map {
 			this.inspectMap(field.Type, localNode, field.Name)
 		} else if field.Type.Kind() == reflect.Ptr {
 			subnode := this.inspectPtr(field.Type.Elem(), localNode, field.Name)
-			this.typeToNode.Put(subnode.TypeName, subnode)
+			if field.Type.Elem().Kind() == reflect.Struct {
+				this.typeToNode.Put(helping.CanonicalTypeName(subnode.TypeName), subnode)
+			}
 		} else {
 			this.addNode(field.Type, localNode, field.Name)
 		}
@@ -124,18 +126,25 @@ func (this *Introspector) inspectStruct(_type reflect.Type, _parent *l8reflect.L
 }
 
 // inspectPtr handles pointer type inspection by delegating to the appropriate handler.
-// Currently only supports pointers to structs.
+// Supports pointers to structs, and pointers to slices, arrays and maps which are
+// modelled as optional containers, the same as their non-pointer counterparts.
 func (this *Introspector) inspectPtr(_type reflect.Type, _parent *l8reflect.L8Node, _fieldName string) *l8reflect.L8Node {
 	switch _type.Kind() {
 	case reflect.Struct:
 		return this.inspectStruct(_type, _parent, _fieldName)
+	case reflect.Slice, reflect.Array:
+		return this.inspectSlice(_type, _parent, _fieldName)
+	case reflect.Map:
+		return this.inspectMap(_type, _parent, _fieldName)
 	}
 	panic("unknown ptr kind " + _type.Kind().String())
 }
 
 // inspectMap inspects a map type and creates appropriate nodes.
 // Handles maps with struct pointer values specially by inspecting the struct.
+// The key type is registered so property paths can convert keys to it by KeyTypeName.
 func (this *Introspector) inspectMap(_type reflect.Type, _parent *l8reflect.L8Node, _fieldName string) *l8reflect.L8Node {
+	this.registry.RegisterType(_type.Key())
 	if _type.Elem().Kind() == reflect.Ptr && _type.Elem().Elem().Kind() == reflect.Struct {
 		subNode := this.inspectStruct(_type.Elem().Elem(), _parent, _fieldName)
 		subNode.IsMap = true
@@ -154,8 +163,9 @@ func (this *Introspector) inspectMap(_type reflect.Type, _parent *l8reflect.L8No
 	}
 }
 
-// inspectSlice inspects a slice type and creates appropriate nodes.
+// inspectSlice inspects a slice or fixed-size array type and creates appropriate nodes.
 // Handles slices of struct pointers specially by inspecting the struct.
+// Arrays are modelled as indexed containers, the same as slices, so both are marked IsSlice.
 func (this *Introspector) inspectSlice(_type reflect.Type, _parent *l8reflect.L8Node, _fieldName string) *l8reflect.L8Node {
 	if _type.Elem().Kind() == reflect.Ptr && _type.Elem().Elem().Kind() == reflect.Struct {
 		subNode := this.inspectStruct(_type.Elem().Elem(), _parent, _fieldName)
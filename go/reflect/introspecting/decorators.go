@@ -20,6 +20,7 @@ package introspecting
 import (
 	"errors"
 	"reflect"
+	"strings"
 
 	"github.com/saichler/l8reflect/go/reflect/helping"
 	"github.com/saichler/l8types/go/ifs"
@@ -102,6 +103,239 @@ func (this *Introspector) AddNoNestedInspection(any interface{}) error {
 	return nil
 }
 
+// AddSkipDecorator marks the specified fields of a type to be skipped.
+// The fields are removed from the type's node tree, so the Updater ignores them,
+// and SkipDecoratorPolicy reports them to the Cloner and DeepEqual.
+// This method is thread-safe.
+func (this *Introspector) AddSkipDecorator(any interface{}, fields ...string) error {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	node, _, err := this.nodeFor(any)
+	if err != nil || node == nil {
+		return err
+	}
+	addDecorator(helping.DecoratorType_Skip, fields, node)
+	for _, field := range fields {
+		attr, ok := node.Attributes[field]
+		if !ok {
+			continue
+		}
+		delete(node.Attributes, field)
+		this.removePaths(attr)
+	}
+	this.addTableView(node)
+	return nil
+}
+
+// removePaths removes a node and its nested nodes from the path cache.
+func (this *Introspector) removePaths(node *l8reflect.L8Node) {
+	for _, attr := range node.Attributes {
+		this.removePaths(attr)
+	}
+	this.pathToNode.Del(helping.NodeCacheKey(node))
+}
+
+// SkipDecoratorPolicy returns a field policy that skips the fields marked by AddSkipDecorator.
+// Inject it into the Cloner, DeepEqual and Updater to exclude the same fields everywhere.
+func (this *Introspector) SkipDecoratorPolicy() helping.FieldPolicy {
+	return helping.FieldPolicyFunc(func(structType reflect.Type, field reflect.StructField) bool {
+		node, ok := this.decoratedNode(structType)
+		if !ok {
+			return false
+		}
+		return helping.HasDecoratorField(node, helping.DecoratorType_Skip, field.Name)
+	})
+}
+
+// AddReferenceDecorator marks the specified fields of a type as references to other objects.
+// Cloning copies the references as is instead of cloning the referenced objects,
+// e.g. the z-side of a many to many relation.
+// This method is thread-safe.
+func (this *Introspector) AddReferenceDecorator(any interface{}, fields ...string) error {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	node, _, err := this.nodeFor(any)
+	if err != nil || node == nil {
+		return err
+	}
+	addDecorator(helping.DecoratorType_Reference, fields, node)
+	return nil
+}
+
+// AddReferenceKeyDecorator marks the specified fields of a type as references to other objects.
+// Cloning copies only the primary key fields of the referenced objects, which must have
+// a primary key decorator. Referenced objects without one are copied as is.
+// This method is thread-safe.
+func (this *Introspector) AddReferenceKeyDecorator(any interface{}, fields ...string) error {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	node, _, err := this.nodeFor(any)
+	if err != nil || node == nil {
+		return err
+	}
+	addDecorator(helping.DecoratorType_ReferenceKey, fields, node)
+	return nil
+}
+
+// ReferenceDecoratorPolicy returns a reference policy reporting the fields marked by
+// AddReferenceDecorator and AddReferenceKeyDecorator. The Introspector's own cloner uses it.
+func (this *Introspector) ReferenceDecoratorPolicy() helping.ReferencePolicy {
+	return helping.ReferencePolicyFunc(func(structType reflect.Type, field reflect.StructField) (bool, []string) {
+		node, ok := this.decoratedNode(structType)
+		if !ok || node.Decorators == nil {
+			return false, nil
+		}
+		if helping.HasDecoratorField(node, helping.DecoratorType_Reference, field.Name) {
+			return true, nil
+		}
+		if !helping.HasDecoratorField(node, helping.DecoratorType_ReferenceKey, field.Name) {
+			return false, nil
+		}
+		elemType := helping.ElemStructType(field.Type)
+		if elemType == nil {
+			return true, nil
+		}
+		elemNode, ok := this.decoratedNode(elemType)
+		if !ok {
+			return true, nil
+		}
+		return true, helping.DecoratorFields(elemNode, l8reflect.L8DecoratorType_Primary)
+	})
+}
+
+// AddSensitiveDecorator marks the specified fields of a type as holding secrets.
+// A Cloner using SensitiveDecoratorPolicy as its redact policy redacts them.
+// This method is thread-safe.
+func (this *Introspector) AddSensitiveDecorator(any interface{}, fields ...string) error {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	node, _, err := this.nodeFor(any)
+	if err != nil || node == nil {
+		return err
+	}
+	addDecorator(helping.DecoratorType_Sensitive, fields, node)
+	return nil
+}
+
+// SensitiveDecoratorPolicy returns a field policy matching the fields marked by
+// AddSensitiveDecorator, to be used with Cloner.SetRedactPolicy.
+func (this *Introspector) SensitiveDecoratorPolicy() helping.FieldPolicy {
+	return helping.FieldPolicyFunc(func(structType reflect.Type, field reflect.StructField) bool {
+		node, ok := this.decoratedNode(structType)
+		if !ok {
+			return false
+		}
+		return helping.HasDecoratorField(node, helping.DecoratorType_Sensitive, field.Name)
+	})
+}
+
+// AddSetDecorator marks the specified slice fields of a type as sets,
+// compared regardless of the order of their elements.
+// This method is thread-safe.
+func (this *Introspector) AddSetDecorator(any interface{}, fields ...string) error {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	node, _, err := this.nodeFor(any)
+	if err != nil || node == nil {
+		return err
+	}
+	addDecorator(helping.DecoratorType_Set, fields, node)
+	return nil
+}
+
+// AddAliasDecorator adds aliases to a field of a type, the names the field is known by
+// in other types, e.g. "DeviceId" for a field "Id". A Mapper matches a field with the fields
+// of the other type named like its aliases, whichever side of the mapping it is on.
+// This method is thread-safe.
+func (this *Introspector) AddAliasDecorator(any interface{}, field string, aliases ...string) error {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	node, _, err := this.nodeFor(any)
+	if err != nil || node == nil {
+		return err
+	}
+	entries := append([]string{}, helping.DecoratorFields(node, helping.DecoratorType_Alias)...)
+	for _, alias := range aliases {
+		entries = append(entries, field+helping.AliasSeparator+alias)
+	}
+	addDecorator(helping.DecoratorType_Alias, entries, node)
+	return nil
+}
+
+// AddRangeDecorator sets the inclusive range of the values of a numeric field of a type,
+// or of the numeric elements of a slice, array or map field, replacing its previous range.
+// A generating.Generator generates values within it.
+// Returns an error if min is greater than max.
+// This method is thread-safe.
+func (this *Introspector) AddRangeDecorator(any interface{}, field string, min, max float64) error {
+	if min > max {
+		return errors.New("Range of field " + field + " has a minimum greater than its maximum")
+	}
+	return this.addBoundsDecorator(helping.DecoratorType_Range, any, field, min, max)
+}
+
+// AddLengthDecorator sets the inclusive range of the length of a string, slice or map field
+// of a type, replacing its previous range. A generating.Generator generates values within it.
+// Returns an error if min is negative or greater than max.
+// This method is thread-safe.
+func (this *Introspector) AddLengthDecorator(any interface{}, field string, min, max int) error {
+	if min < 0 || min > max {
+		return errors.New("Length of field " + field + " must have a non negative minimum not greater than its maximum")
+	}
+	return this.addBoundsDecorator(helping.DecoratorType_Length, any, field, float64(min), float64(max))
+}
+
+// addBoundsDecorator sets the bounds of a field in a range or length decorator.
+func (this *Introspector) addBoundsDecorator(decoratorType l8reflect.L8DecoratorType, any interface{}, field string, min, max float64) error {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	node, _, err := this.nodeFor(any)
+	if err != nil || node == nil {
+		return err
+	}
+	entries := make([]string, 0)
+	for _, entry := range helping.DecoratorFields(node, decoratorType) {
+		if !strings.HasPrefix(entry, field+helping.AliasSeparator) {
+			entries = append(entries, entry)
+		}
+	}
+	entries = append(entries, helping.BoundsEntry(field, min, max))
+	addDecorator(decoratorType, entries, node)
+	return nil
+}
+
+// SliceOrderDecoratorPolicy returns a slice order policy for DeepEqual.SetSliceOrderPolicy.
+// Slices of elements with a primary key decorator (or else a unique key decorator) are
+// compared by key, and the other slice fields marked by AddSetDecorator as multisets.
+func (this *Introspector) SliceOrderDecoratorPolicy() helping.SliceOrderPolicy {
+	return helping.SliceOrderPolicyFunc(func(structType reflect.Type, field reflect.StructField) (bool, []string) {
+		elemType := helping.ElemStructType(field.Type)
+		if elemType != nil {
+			elemNode, ok := this.decoratedNode(elemType)
+			if ok {
+				keyFields := helping.DecoratorFields(elemNode, l8reflect.L8DecoratorType_Primary)
+				if len(keyFields) == 0 {
+					keyFields = helping.DecoratorFields(elemNode, l8reflect.L8DecoratorType_Unique)
+				}
+				if len(keyFields) > 0 {
+					return true, keyFields
+				}
+			}
+		}
+		node, ok := this.decoratedNode(structType)
+		if !ok {
+			return false, nil
+		}
+		return helping.HasDecoratorField(node, helping.DecoratorType_Set, field.Name), nil
+	})
+}
+
+// decoratedNode returns the root node of a type, which holds the type's decorators.
+// Nested nodes of the same type are copies made at inspection time and may lack them.
+func (this *Introspector) decoratedNode(typ reflect.Type) (*l8reflect.L8Node, bool) {
+	return this.Node(helping.CanonicalTypeName(typ.Name()))
+}
+
 // NodeFor retrieves the L8Node and reflect.Value for a given interface.
 // Returns an error if the input is nil or invalid.
 // This method is thread-safe.
@@ -121,7 +355,7 @@ func (this *Introspector) nodeFor(any interface{}) (*l8reflect.L8Node, reflect.V
 	if e != nil {
 		return nil, v, e
 	}
-	node, ok := this.Node(v.Type().Name())
+	node, ok := this.Node(helping.CanonicalTypeName(v.Type().Name()))
 	if !ok {
 		node, e = this.inspect(any)
 		if e != nil {
@@ -49,7 +49,9 @@ type Introspector struct {
 	cloner *cloning.Cloner
 	// tableViews stores table view representations of types
 	tableViews *maps.SyncMap
-	mutex      *sync.Mutex
+	// fieldPolicy decides which struct fields are not inspected
+	fieldPolicy helping.FieldPolicy
+	mutex       *sync.Mutex
 }
 
 // NewIntrospect creates a new Introspector with the given type registry.
@@ -58,13 +60,25 @@ func NewIntrospect(registry ifs.IRegistry) *Introspector {
 	introspector := &Introspector{}
 	introspector.mutex = &sync.Mutex{}
 	introspector.registry = registry
+	introspector.fieldPolicy = helping.DefaultFieldPolicy
 	introspector.cloner = cloning.NewCloner()
+	introspector.cloner.SetReferencePolicy(introspector.ReferenceDecoratorPolicy())
 	introspector.pathToNode = NewIntrospectNodeMap()
 	introspector.typeToNode = NewIntrospectNodeMap()
 	introspector.tableViews = maps.NewSyncMap()
 	return introspector
 }
 
+// SetFieldPolicy sets the policies deciding which struct fields are left out of the node tree.
+// The default rule (see helping.IgnoreName) is always applied in addition to the given policies.
+// It only affects types inspected after the call.
+// This method is thread-safe.
+func (this *Introspector) SetFieldPolicy(policies ...helping.FieldPolicy) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.fieldPolicy = helping.NewFieldPolicy(policies...)
+}
+
 // Registry returns the type registry associated with this Introspector.
 func (this *Introspector) Registry() ifs.IRegistry {
 	return this.registry
@@ -94,7 +108,7 @@ func (this *Introspector) inspect(any interface{}) (*l8reflect.L8Node, error) {
 	if t.Kind() != reflect.Struct {
 		return nil, errors.New("Cannot introspect a value that is not a struct")
 	}
-	localNode, ok := this.pathToNode.Get(strings.ToLower(t.Name()))
+	localNode, ok := this.pathToNode.Get(strings.ToLower(helping.CanonicalTypeName(t.Name())))
 	if ok {
 		return localNode, nil
 	}
@@ -121,8 +135,9 @@ func (this *Introspector) NodeByType(typ reflect.Type) (*l8reflect.L8Node, bool)
 }
 
 // NodeByTypeName retrieves an L8Node by type name.
+// Generic instantiations can be looked up by either their reflect name or canonical name.
 func (this *Introspector) NodeByTypeName(name string) (*l8reflect.L8Node, bool) {
-	return this.typeToNode.Get(name)
+	return this.typeToNode.Get(helping.CanonicalTypeName(name))
 }
 
 // Nodes returns a list of L8Nodes, optionally filtered by leaf or root status.
@@ -156,8 +171,17 @@ func (this *Introspector) Kind(node *l8reflect.L8Node) reflect.Kind {
 }
 
 // Clone performs a deep clone of the given value using the internal cloner.
+// Fields marked by the reference decorators are copied as references or keys.
+// Returns nil if the value cannot be cloned, see CloneWithError for the error.
 func (this *Introspector) Clone(any interface{}) interface{} {
-	return this.cloner.Clone(any)
+	clone, _ := this.CloneWithError(any)
+	return clone
+}
+
+// CloneWithError clones the value like Clone, but returns an error instead of nil
+// if the value cannot be cloned.
+func (this *Introspector) CloneWithError(any interface{}) (interface{}, error) {
+	return this.cloner.CloneWithError(any)
 }
 
 // addTableView creates and stores a table view representation for a node.
@@ -171,12 +195,12 @@ func (this *Introspector) addTableView(node *l8reflect.L8Node) {
 			tv.SubTables = append(tv.SubTables, attr)
 		}
 	}
-	this.tableViews.Put(node.TypeName, tv)
+	this.tableViews.Put(helping.CanonicalTypeName(node.TypeName), tv)
 }
 
 // TableView retrieves a table view by type name.
 func (this *Introspector) TableView(name string) (*l8reflect.L8TableView, bool) {
-	tv, ok := this.tableViews.Get(name)
+	tv, ok := this.tableViews.Get(helping.CanonicalTypeName(name))
 	if !ok {
 		return nil, ok
 	}
@@ -207,6 +231,6 @@ func (this *Introspector) clean(node *l8reflect.L8Node) {
 			this.clean(attr)
 		}
 	}
-	this.typeToNode.Del(node.TypeName)
+	this.typeToNode.Del(helping.CanonicalTypeName(node.TypeName))
 	this.pathToNode.Del(helping.NodeCacheKey(node))
 }
@@ -0,0 +1,235 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the conversion of the mapped values into their target types,
+// and the compatibility of the target and source types deciding which fields are mapped.
+
+package mapping
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/saichler/l8reflect/go/reflect/properties"
+)
+
+// stringerType is the reflect.Type of the fmt.Stringer interface.
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// initConverters initializes the conversion function registry with handlers for all
+// supported target kinds.
+func (this *Mapper) initConverters() {
+	this.converters = make(map[reflect.Kind]func(reflect.Type, reflect.Value, *mapLoop) reflect.Value)
+	for _, kind := range []reflect.Kind{reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128,
+		reflect.Bool, reflect.String} {
+		this.converters[kind] = this.leafConvert
+	}
+	this.converters[reflect.Ptr] = this.ptrConvert
+	this.converters[reflect.Struct] = this.structConvert
+	this.converters[reflect.Slice] = this.sliceConvert
+	this.converters[reflect.Array] = this.arrayConvert
+	this.converters[reflect.Map] = this.mapConvert
+}
+
+// convert converts a source value into a new value of the target type.
+// Values of the target type are deep cloned, incompatible values are mapped to zero.
+// A value that fails to clone is mapped to zero and its error recorded in loop.
+func (this *Mapper) convert(targetType reflect.Type, source reflect.Value, loop *mapLoop) reflect.Value {
+	if source.IsValid() && source.Kind() == reflect.Interface {
+		source = source.Elem()
+	}
+	if !source.IsValid() {
+		return reflect.Zero(targetType)
+	}
+	if source.Type() == targetType || (targetType.Kind() == reflect.Interface && source.Type().AssignableTo(targetType)) {
+		cloned, err := this.cloner.CloneWithError(source.Interface())
+		if err != nil {
+			loop.fail(err)
+			return reflect.Zero(targetType)
+		}
+		clone := reflect.ValueOf(cloned)
+		if !clone.IsValid() {
+			return reflect.Zero(targetType)
+		}
+		return clone
+	}
+	converter := this.converters[targetType.Kind()]
+	if converter == nil {
+		return reflect.Zero(targetType)
+	}
+	return converter(targetType, source, loop)
+}
+
+// deref returns the value a pointer points to, false if the pointer is nil.
+func deref(source reflect.Value) (reflect.Value, bool) {
+	for source.Kind() == reflect.Ptr {
+		if source.IsNil() {
+			return source, false
+		}
+		source = source.Elem()
+	}
+	return source, true
+}
+
+// leafConvert converts a primitive value via properties.ConvertValue.
+// Enums are converted to strings by name, and from strings by their registered values.
+func (this *Mapper) leafConvert(targetType reflect.Type, source reflect.Value, loop *mapLoop) reflect.Value {
+	source, ok := deref(source)
+	if !ok {
+		return reflect.Zero(targetType)
+	}
+	if targetType.Kind() == reflect.String && properties.IsNumeric(source.Kind()) &&
+		source.Type().Implements(stringerType) {
+		return reflect.ValueOf(source.Interface().(fmt.Stringer).String()).Convert(targetType)
+	}
+	if source.Kind() == reflect.String && properties.IsNumeric(targetType.Kind()) {
+		values, ok := this.enums[targetType]
+		if !ok {
+			return reflect.Zero(targetType)
+		}
+		source = reflect.ValueOf(values[source.String()])
+	}
+	converted := properties.ConvertValue(reflect.New(targetType).Elem(), source)
+	if converted.Kind() != targetType.Kind() {
+		return reflect.Zero(targetType)
+	}
+	return converted.Convert(targetType)
+}
+
+// ptrConvert converts a value into a new pointer to its converted value.
+// A source pointer already mapped to the target type returns the same target pointer.
+func (this *Mapper) ptrConvert(targetType reflect.Type, source reflect.Value, loop *mapLoop) reflect.Value {
+	var key mapKey
+	tracked := source.Kind() == reflect.Ptr && !source.IsNil()
+	if tracked {
+		key = mapKey{source: source.Pointer(), target: targetType}
+		if target, ok := loop.targets[key]; ok {
+			return target
+		}
+	}
+	source, ok := deref(source)
+	if !ok {
+		return reflect.Zero(targetType)
+	}
+	newPtr := reflect.New(targetType.Elem())
+	target := newPtr.Convert(targetType)
+	if tracked {
+		// stored before converting, so cycles back to the source end at the target
+		loop.targets[key] = target
+	}
+	newPtr.Elem().Set(this.convert(targetType.Elem(), source, loop))
+	return target
+}
+
+// structConvert maps a struct into a new struct of the target type.
+func (this *Mapper) structConvert(targetType reflect.Type, source reflect.Value, loop *mapLoop) reflect.Value {
+	target := reflect.New(targetType).Elem()
+	source, ok := deref(source)
+	if !ok || source.Kind() != reflect.Struct {
+		return target
+	}
+	plan, err := this.plan(source.Type(), targetType)
+	if err != nil {
+		return target
+	}
+	this.mapFields(plan, source, target, loop)
+	return target
+}
+
+// sliceConvert converts a slice or array into a new slice of the converted elements.
+func (this *Mapper) sliceConvert(targetType reflect.Type, source reflect.Value, loop *mapLoop) reflect.Value {
+	source, ok := deref(source)
+	if !ok || (source.Kind() == reflect.Slice && source.IsNil()) {
+		return reflect.Zero(targetType)
+	}
+	if source.Kind() != reflect.Slice && source.Kind() != reflect.Array {
+		return reflect.Zero(targetType)
+	}
+	target := reflect.MakeSlice(targetType, source.Len(), source.Len())
+	for i := 0; i < source.Len(); i++ {
+		target.Index(i).Set(this.convert(targetType.Elem(), source.Index(i), loop))
+	}
+	return target
+}
+
+// arrayConvert converts a slice or array into a new array of the converted elements,
+// truncated or zero padded to the array length.
+func (this *Mapper) arrayConvert(targetType reflect.Type, source reflect.Value, loop *mapLoop) reflect.Value {
+	target := reflect.New(targetType).Elem()
+	source, ok := deref(source)
+	if !ok || (source.Kind() != reflect.Slice && source.Kind() != reflect.Array) {
+		return target
+	}
+	for i := 0; i < source.Len() && i < target.Len(); i++ {
+		target.Index(i).Set(this.convert(targetType.Elem(), source.Index(i), loop))
+	}
+	return target
+}
+
+// mapConvert converts a map into a new map of the converted keys and values.
+func (this *Mapper) mapConvert(targetType reflect.Type, source reflect.Value, loop *mapLoop) reflect.Value {
+	source, ok := deref(source)
+	if !ok || source.Kind() != reflect.Map || source.IsNil() {
+		return reflect.Zero(targetType)
+	}
+	target := reflect.MakeMapWithSize(targetType, source.Len())
+	iter := source.MapRange()
+	for iter.Next() {
+		target.SetMapIndex(this.convert(targetType.Key(), iter.Key(), loop), this.convert(targetType.Elem(), iter.Value(), loop))
+	}
+	return target
+}
+
+// compatible returns true if values of the source type can be mapped to the target type.
+func (this *Mapper) compatible(targetType, sourceType reflect.Type) bool {
+	for targetType.Kind() == reflect.Ptr {
+		targetType = targetType.Elem()
+	}
+	for sourceType.Kind() == reflect.Ptr {
+		sourceType = sourceType.Elem()
+	}
+	if targetType == sourceType {
+		return true
+	}
+	switch targetType.Kind() {
+	case reflect.Struct:
+		return sourceType.Kind() == reflect.Struct
+	case reflect.Slice, reflect.Array:
+		return (sourceType.Kind() == reflect.Slice || sourceType.Kind() == reflect.Array) &&
+			this.compatible(targetType.Elem(), sourceType.Elem())
+	case reflect.Map:
+		return sourceType.Kind() == reflect.Map && this.compatible(targetType.Key(), sourceType.Key()) &&
+			this.compatible(targetType.Elem(), sourceType.Elem())
+	case reflect.Interface:
+		return sourceType.AssignableTo(targetType)
+	case reflect.String:
+		return isLeaf(sourceType.Kind())
+	case reflect.Bool:
+		return isLeaf(sourceType.Kind())
+	}
+	if !properties.IsNumeric(targetType.Kind()) {
+		return false
+	}
+	if sourceType.Kind() == reflect.String {
+		_, ok := this.enums[targetType]
+		return ok
+	}
+	return properties.IsNumeric(sourceType.Kind()) || sourceType.Kind() == reflect.Bool
+}
+
+// isLeaf returns true for the kinds converted by properties.ConvertValue.
+func isLeaf(kind reflect.Kind) bool {
+	return properties.IsNumeric(kind) || kind == reflect.Bool || kind == reflect.String
+}
@@ -0,0 +1,352 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mapping provides mapping between struct types sharing most of their fields,
+// e.g. API DTOs and internal models.
+//
+// Key features:
+//   - Fields matched by name, case-insensitively, or by alias
+//   - Aliases registered on the Mapper or by the Introspector's alias decorator
+//   - Values converted via properties.ConvertValue, e.g. int32 to int64
+//   - Enums mapped to and from their names
+//   - Nested structs, pointers, slices, arrays and maps mapped recursively
+//   - Shared and cyclic pointers mapped to shared and cyclic pointers
+//   - Report of the fields left unmapped on both sides
+package mapping
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+	"github.com/saichler/l8reflect/go/reflect/helping"
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8types/go/types/l8reflect"
+)
+
+// Mapper maps the values of a struct type into another struct type.
+// The fields of both types are taken from their Introspector nodes and matched once
+// per pair of types.
+type Mapper struct {
+	// resources provides the Introspector nodes of the mapped types
+	resources ifs.IResources
+	// converters maps each target reflect.Kind to its corresponding conversion function
+	converters map[reflect.Kind]func(reflect.Type, reflect.Value, *mapLoop) reflect.Value
+	// aliases maps the target type names to their field aliases
+	aliases map[string]map[string][]string
+	// enums maps enum types to the values of their names
+	enums map[reflect.Type]map[string]int32
+	// cloner copies the values of the same type on both sides
+	cloner *cloning.Cloner
+	// plans caches the field matching of each pair of types
+	plans map[planKey]*mapPlan
+	mutex *sync.Mutex
+}
+
+// MapReport lists the fields left unmapped by a mapping, by property path, e.g. "devicedto.ports.speed".
+type MapReport struct {
+	// UnmappedSource lists the source fields not mapped to any target field
+	UnmappedSource []string
+	// UnmappedTarget lists the target fields no source field is mapped to
+	UnmappedTarget []string
+}
+
+// planKey identifies the mapping between a pair of struct types.
+type planKey struct {
+	source reflect.Type
+	target reflect.Type
+}
+
+// mapPlan is the field matching between a pair of struct types.
+type mapPlan struct {
+	// fields holds the matched fields
+	fields []fieldPair
+	// unmappedSource holds the names of the source fields left unmapped
+	unmappedSource []string
+	// unmappedTarget holds the names of the target fields left unmapped
+	unmappedTarget []string
+}
+
+// mapLoop tracks the targets of the pointers already mapped by a Map, so shared and
+// cyclic source pointers are mapped to shared and cyclic target pointers.
+type mapLoop struct {
+	// targets maps each source pointer and target type to the target mapped from it
+	targets map[mapKey]reflect.Value
+	// err is the first error of cloning a value, e.g. a *cloning.LimitError
+	err error
+}
+
+// mapKey identifies the target of a type mapped from a source pointer.
+type mapKey struct {
+	source uintptr
+	target reflect.Type
+}
+
+// fail records the first error of the mapping.
+func (this *mapLoop) fail(err error) {
+	if this.err == nil {
+		this.err = err
+	}
+}
+
+// fieldPair is a target field and the source field mapped to it, by field index.
+type fieldPair struct {
+	target int
+	source int
+}
+
+// NewMapper creates a new Mapper taking the nodes of the mapped types from the
+// resources' Introspector. Types not inspected yet are inspected when first mapped.
+func NewMapper(resources ifs.IResources) *Mapper {
+	mapper := &Mapper{}
+	mapper.resources = resources
+	mapper.aliases = make(map[string]map[string][]string)
+	mapper.enums = make(map[reflect.Type]map[string]int32)
+	mapper.cloner = cloning.NewCloner()
+	mapper.plans = make(map[planKey]*mapPlan)
+	mapper.mutex = &sync.Mutex{}
+	mapper.initConverters()
+	return mapper
+}
+
+// AddAlias makes the field of the target type match the source field of any source type,
+// in addition to the source field of the same name.
+// Aliases should be added before the Mapper is used concurrently.
+func (this *Mapper) AddAlias(target interface{}, targetField, sourceField string) {
+	_, typ := helping.ValueAndType(target)
+	typeName := helping.CanonicalTypeName(typ.Name())
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	aliases, ok := this.aliases[typeName]
+	if !ok {
+		aliases = make(map[string][]string)
+		this.aliases[typeName] = aliases
+	}
+	aliases[targetField] = append(aliases[targetField], sourceField)
+	this.plans = make(map[planKey]*mapPlan)
+}
+
+// SetLimits sets the limits of each value of the same type on both sides, which is
+// deep cloned, nil for no limits. A value exceeding them fails the Map with a
+// *cloning.LimitError. It must be called before the Mapper is used.
+func (this *Mapper) SetLimits(limits *cloning.Limits) {
+	this.cloner.SetLimits(limits)
+}
+
+// RegisterEnum registers the values of the names of an enum type, e.g. the Severity_value
+// map generated by protobuf, so strings are mapped to the enum. Enums are always mapped
+// to strings by their String method. Unknown names are mapped to the zero value.
+func (this *Mapper) RegisterEnum(enum interface{}, values map[string]int32) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.enums[reflect.TypeOf(enum)] = values
+	this.plans = make(map[planKey]*mapPlan)
+}
+
+// Map maps the source struct, or pointer to struct, into the struct target points to.
+// Every target field matched by a source field is overwritten with the converted value,
+// the other target fields are left as is. Values of the same type on both sides are
+// deep cloned. Other source pointers reached twice are mapped to the same target pointer,
+// and pointers back to source are mapped to target. Returns the fields left unmapped on
+// both sides, or an error if the values are not structs, target is not a non nil pointer
+// or a value fails to clone, e.g. a *cloning.LimitError, leaving target partially mapped.
+func (this *Mapper) Map(source, target interface{}) (*MapReport, error) {
+	sourceValue := reflect.ValueOf(source)
+	targetValue := reflect.ValueOf(target)
+	if !sourceValue.IsValid() || !targetValue.IsValid() {
+		return nil, errors.New("Map: source and target must not be nil")
+	}
+	if targetValue.Kind() != reflect.Ptr || targetValue.IsNil() || targetValue.Elem().Kind() != reflect.Struct {
+		return nil, errors.New("Map: target must be a non nil pointer to a struct")
+	}
+	loop := &mapLoop{targets: make(map[mapKey]reflect.Value)}
+	if sourceValue.Kind() == reflect.Ptr {
+		if sourceValue.IsNil() {
+			return nil, errors.New("Map: source must not be nil")
+		}
+		// pointers back to the source are mapped to target
+		loop.targets[mapKey{source: sourceValue.Pointer(), target: targetValue.Type()}] = targetValue
+		sourceValue = sourceValue.Elem()
+	}
+	if sourceValue.Kind() != reflect.Struct {
+		return nil, errors.New("Map: source must be a struct or a pointer to a struct")
+	}
+	targetValue = targetValue.Elem()
+	plan, err := this.plan(sourceValue.Type(), targetValue.Type())
+	if err != nil {
+		return nil, err
+	}
+	this.mapFields(plan, sourceValue, targetValue, loop)
+	if loop.err != nil {
+		return nil, loop.err
+	}
+	report := &MapReport{UnmappedSource: make([]string, 0), UnmappedTarget: make([]string, 0)}
+	err = this.report(sourceValue.Type(), targetValue.Type(), rootName(sourceValue.Type()),
+		rootName(targetValue.Type()), report, make(map[planKey]bool))
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// mapFields sets the matched target fields to the converted values of their source fields.
+func (this *Mapper) mapFields(plan *mapPlan, sourceValue, targetValue reflect.Value, loop *mapLoop) {
+	for _, pair := range plan.fields {
+		targetField := targetValue.Field(pair.target)
+		targetField.Set(this.convert(targetField.Type(), sourceValue.Field(pair.source), loop))
+	}
+}
+
+// report adds the unmapped fields of a pair of struct types and of their nested struct
+// types to the report, each pair of types once.
+func (this *Mapper) report(sourceType, targetType reflect.Type, sourcePath, targetPath string, report *MapReport, visited map[planKey]bool) error {
+	key := planKey{source: sourceType, target: targetType}
+	if visited[key] || sourceType == targetType {
+		return nil
+	}
+	visited[key] = true
+	plan, err := this.plan(sourceType, targetType)
+	if err != nil {
+		return err
+	}
+	for _, name := range plan.unmappedSource {
+		report.UnmappedSource = append(report.UnmappedSource, sourcePath+"."+strings.ToLower(name))
+	}
+	for _, name := range plan.unmappedTarget {
+		report.UnmappedTarget = append(report.UnmappedTarget, targetPath+"."+strings.ToLower(name))
+	}
+	for _, pair := range plan.fields {
+		sourceField := sourceType.Field(pair.source)
+		targetField := targetType.Field(pair.target)
+		sourceElem := structElem(sourceField.Type)
+		targetElem := structElem(targetField.Type)
+		if sourceElem == nil || targetElem == nil {
+			continue
+		}
+		err = this.report(sourceElem, targetElem, sourcePath+"."+strings.ToLower(sourceField.Name),
+			targetPath+"."+strings.ToLower(targetField.Name), report, visited)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// plan returns the field matching of a pair of struct types, building it on first use.
+func (this *Mapper) plan(sourceType, targetType reflect.Type) (*mapPlan, error) {
+	key := planKey{source: sourceType, target: targetType}
+	this.mutex.Lock()
+	plan, ok := this.plans[key]
+	this.mutex.Unlock()
+	if ok {
+		return plan, nil
+	}
+	sourceNode, err := this.node(sourceType)
+	if err != nil {
+		return nil, err
+	}
+	targetNode, err := this.node(targetType)
+	if err != nil {
+		return nil, err
+	}
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	plan = this.newPlan(sourceType, targetType, sourceNode, targetNode)
+	this.plans[key] = plan
+	return plan, nil
+}
+
+// newPlan matches the fields of the target node with the fields of the source node.
+// A target field is matched by the first source field whose name, case-insensitively,
+// is the target field's name or alias, or which has the target field's name as an alias.
+// Matched fields of incompatible types are left unmapped.
+func (this *Mapper) newPlan(sourceType, targetType reflect.Type, sourceNode, targetNode *l8reflect.L8Node) *mapPlan {
+	plan := &mapPlan{fields: make([]fieldPair, 0)}
+	mapped := make(map[int]bool)
+	targetAliases := this.aliases[helping.CanonicalTypeName(targetType.Name())]
+	for i := 0; i < targetType.NumField(); i++ {
+		targetField := targetType.Field(i)
+		if _, ok := targetNode.Attributes[targetField.Name]; !ok {
+			continue
+		}
+		names := append([]string{targetField.Name}, targetAliases[targetField.Name]...)
+		names = append(names, helping.FieldAliases(targetNode, targetField.Name)...)
+		source := -1
+		for j := 0; j < sourceType.NumField() && source == -1; j++ {
+			sourceField := sourceType.Field(j)
+			if _, ok := sourceNode.Attributes[sourceField.Name]; !ok || mapped[j] {
+				continue
+			}
+			if matchName(sourceField.Name, names) ||
+				matchName(targetField.Name, helping.FieldAliases(sourceNode, sourceField.Name)) {
+				source = j
+			}
+		}
+		if source == -1 || !this.compatible(targetField.Type, sourceType.Field(source).Type) {
+			plan.unmappedTarget = append(plan.unmappedTarget, targetField.Name)
+			continue
+		}
+		mapped[source] = true
+		plan.fields = append(plan.fields, fieldPair{target: i, source: source})
+	}
+	for j := 0; j < sourceType.NumField(); j++ {
+		sourceField := sourceType.Field(j)
+		if _, ok := sourceNode.Attributes[sourceField.Name]; ok && !mapped[j] {
+			plan.unmappedSource = append(plan.unmappedSource, sourceField.Name)
+		}
+	}
+	return plan
+}
+
+// node returns the Introspector node of a struct type, inspecting the type if needed.
+func (this *Mapper) node(typ reflect.Type) (*l8reflect.L8Node, error) {
+	introspector := this.resources.Introspector()
+	// nested nodes of the same type are copies that may lack the decorators
+	node, ok := introspector.Node(helping.CanonicalTypeName(typ.Name()))
+	if ok {
+		return node, nil
+	}
+	return introspector.Inspect(reflect.New(typ).Interface())
+}
+
+// matchName returns true if the name equals one of the names, case-insensitively.
+func matchName(name string, names []string) bool {
+	for _, other := range names {
+		if strings.EqualFold(name, other) {
+			return true
+		}
+	}
+	return false
+}
+
+// structElem returns the struct type a type holds, through pointers, slices, arrays
+// and map values, or nil if it holds no struct.
+func structElem(typ reflect.Type) reflect.Type {
+	for {
+		switch typ.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			typ = typ.Elem()
+		case reflect.Struct:
+			return typ
+		default:
+			return nil
+		}
+	}
+}
+
+// rootName returns the lowercase canonical name of a type, starting its property paths.
+func rootName(typ reflect.Type) string {
+	return strings.ToLower(helping.CanonicalTypeName(typ.Name()))
+}
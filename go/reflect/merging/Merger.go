@@ -0,0 +1,396 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package merging provides the merge of several instances of a type into one,
+// e.g. for reconciling the inventory reported by multiple collectors.
+//
+// Key features:
+//   - Per property path strategies, with a default strategy for the other paths
+//   - First non zero value, last value or highest priority source wins
+//   - Union of map keys, each key merged from the sources holding it
+//   - Concatenation of slices
+//   - Report of the source providing each merged value
+//   - Shared and cyclic pointers merged once per tuple of source pointers
+package merging
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+	"github.com/saichler/l8reflect/go/reflect/helping"
+)
+
+// Strategy decides how the values of a property path are merged.
+type Strategy int
+
+const (
+	// FirstNonZero takes the first non zero value, in the order of the sources
+	FirstNonZero Strategy = iota
+	// LastWins takes the value of the last source holding the property, zero values included
+	LastWins
+	// HighestPriority takes the non zero value of the source with the highest priority,
+	// the first of them on equal priorities
+	HighestPriority
+	// UnionKeys merges maps into the union of their keys, the values of a key held by
+	// several sources are merged by the strategies of the paths under the map
+	UnionKeys
+	// Concatenate merges slices into the concatenation of their elements, in the order
+	// of the sources
+	Concatenate
+)
+
+// Source is an instance to merge.
+type Source struct {
+	// Name identifies the source, e.g. the collector that reported the instance
+	Name string
+	// Priority ranks the source for the HighestPriority strategy
+	Priority int
+	// Value is the instance, a struct or a pointer to a struct, nil if the source has none
+	Value interface{}
+}
+
+// MergeResult is the outcome of a merge.
+type MergeResult struct {
+	// Value is the merged instance, of the same type as the sources' values
+	Value interface{}
+	// Sources maps the property id of each merged value, e.g. "device.ports<{24}eth0>.speed",
+	// to the index of the source providing it. Zero values provided by no source are absent.
+	Sources map[string]int
+}
+
+// Merger merges several instances of a type, with a strategy per property path.
+type Merger struct {
+	// strategies maps the lowercase property paths, without keys, to their strategies
+	strategies map[string]Strategy
+	// defaultStrategy merges the values of the paths without a strategy
+	defaultStrategy Strategy
+	// fieldPolicy decides which struct fields are not merged
+	fieldPolicy helping.FieldPolicy
+	// cloner copies the merged values, so the result shares nothing with the sources
+	cloner *cloning.Cloner
+}
+
+// mergeState holds the state of a single merge.
+type mergeState struct {
+	sources    []*Source
+	provenance map[string]int
+	// merged maps the tuples of source pointers already merged to their result,
+	// so shared and cyclic pointers are merged once
+	merged map[mergeKey]reflect.Value
+}
+
+// mergeKey identifies a merge of pointers, by its type and the tuple of the source pointers.
+type mergeKey struct {
+	typ      reflect.Type
+	pointers string
+}
+
+// NewMerger creates a new Merger whose default strategy is FirstNonZero.
+func NewMerger() *Merger {
+	merger := &Merger{}
+	merger.strategies = make(map[string]Strategy)
+	merger.defaultStrategy = FirstNonZero
+	merger.fieldPolicy = helping.DefaultFieldPolicy
+	merger.cloner = cloning.NewCloner()
+	return merger
+}
+
+// SetStrategy sets the strategy of a property path, given without keys and starting with
+// the lowercase type name, e.g. "device.ports" or "device.ports.speed".
+// Struct paths without a strategy are merged field by field. Other paths without a
+// strategy use the default strategy, and so do the elements of maps and slices.
+// Returns an error if the strategy is unknown.
+func (this *Merger) SetStrategy(path string, strategy Strategy) error {
+	if strategy < FirstNonZero || strategy > Concatenate {
+		return errors.New("Unknown merge strategy for path " + path)
+	}
+	this.strategies[strings.ToLower(path)] = strategy
+	return nil
+}
+
+// SetDefaultStrategy sets the strategy of the paths without one.
+// Returns an error for UnionKeys and Concatenate, which only apply to maps and slices.
+func (this *Merger) SetDefaultStrategy(strategy Strategy) error {
+	if strategy != FirstNonZero && strategy != LastWins && strategy != HighestPriority {
+		return errors.New("The default merge strategy must be FirstNonZero, LastWins or HighestPriority")
+	}
+	this.defaultStrategy = strategy
+	return nil
+}
+
+// SetLimits sets the limits of each value the Merger deep clones from the sources,
+// nil for no limits. A value exceeding them fails the Merge with a *cloning.LimitError.
+// It must be called before the Merger is used.
+func (this *Merger) SetLimits(limits *cloning.Limits) {
+	this.cloner.SetLimits(limits)
+}
+
+// SetFieldPolicy sets the policies deciding which struct fields are left zero in the result.
+// The default rule (see helping.IgnoreName) is always applied in addition to the given policies.
+func (this *Merger) SetFieldPolicy(policies ...helping.FieldPolicy) {
+	this.fieldPolicy = helping.NewFieldPolicy(policies...)
+	this.cloner.SetFieldPolicy(policies...)
+}
+
+// Merge merges the values of the sources into a new instance, reporting which source
+// provided each merged value. Sources without a value, or with a nil pointer, are skipped.
+// Returns an error if no source has a value, the values are of different types or not
+// structs, a UnionKeys or Concatenate strategy is set on a path that is not a map or slice,
+// or a value fails to clone, e.g. a *cloning.LimitError.
+func (this *Merger) Merge(sources ...*Source) (*MergeResult, error) {
+	var typ reflect.Type
+	values := make([]reflect.Value, len(sources))
+	for i, source := range sources {
+		if source == nil || source.Value == nil {
+			continue
+		}
+		value := reflect.ValueOf(source.Value)
+		if value.Kind() == reflect.Ptr && value.IsNil() {
+			continue
+		}
+		values[i] = value
+		if typ == nil {
+			typ = values[i].Type()
+		} else if values[i].Type() != typ {
+			return nil, errors.New("Merge: source " + source.Name + " is a " + values[i].Type().String() +
+				", expected a " + typ.String())
+		}
+	}
+	if typ == nil {
+		return nil, errors.New("Merge: no source has a value")
+	}
+	if !isStruct(typ) {
+		return nil, errors.New("Merge: cannot merge a " + typ.String() + ", expected a struct")
+	}
+	state := &mergeState{sources: sources, provenance: make(map[string]int), merged: make(map[mergeKey]reflect.Value)}
+	root := strings.ToLower(helping.CanonicalTypeName(elemType(typ).Name()))
+	merged, err := this.merge(typ, values, root, root, true, state)
+	if err != nil {
+		return nil, err
+	}
+	return &MergeResult{Value: merged.Interface(), Sources: state.provenance}, nil
+}
+
+// merge merges the values of a property, one per source, invalid where a source lacks it.
+// path is the property path without keys, propertyId the one with keys. The strategy of
+// path applies only if lookup is true, i.e. not to the elements of maps and slices.
+func (this *Merger) merge(typ reflect.Type, values []reflect.Value, path, propertyId string, lookup bool, state *mergeState) (reflect.Value, error) {
+	strategy, ok := this.strategies[path]
+	if !ok || !lookup {
+		if isStruct(typ) {
+			return this.mergeStruct(typ, values, path, propertyId, state)
+		}
+		strategy = this.defaultStrategy
+	}
+	switch strategy {
+	case FirstNonZero:
+		for i, value := range values {
+			if value.IsValid() && !value.IsZero() {
+				return this.pick(typ, values, i, propertyId, state)
+			}
+		}
+	case LastWins:
+		for i := len(values) - 1; i >= 0; i-- {
+			if values[i].IsValid() {
+				return this.pick(typ, values, i, propertyId, state)
+			}
+		}
+	case HighestPriority:
+		highest := -1
+		for i, value := range values {
+			if value.IsValid() && !value.IsZero() &&
+				(highest == -1 || state.sources[i].Priority > state.sources[highest].Priority) {
+				highest = i
+			}
+		}
+		if highest != -1 {
+			return this.pick(typ, values, highest, propertyId, state)
+		}
+	case UnionKeys:
+		if typ.Kind() != reflect.Map {
+			return reflect.Value{}, errors.New("UnionKeys strategy set on " + path + ", which is not a map")
+		}
+		return this.unionKeys(typ, values, path, propertyId, state)
+	case Concatenate:
+		if typ.Kind() != reflect.Slice {
+			return reflect.Value{}, errors.New("Concatenate strategy set on " + path + ", which is not a slice")
+		}
+		return this.concatenate(typ, values, propertyId, state)
+	}
+	return reflect.Zero(typ), nil
+}
+
+// mergeStruct merges structs, or pointers to structs, field by field.
+// Pointers are merged once per tuple of source pointers, a tuple reached again, e.g.
+// through a cycle, returns the result already being built for it.
+func (this *Merger) mergeStruct(typ reflect.Type, values []reflect.Value, path, propertyId string, state *mergeState) (reflect.Value, error) {
+	structType := elemType(typ)
+	elems := make([]reflect.Value, len(values))
+	present := false
+	for i, value := range values {
+		if !value.IsValid() || (value.Kind() == reflect.Ptr && value.IsNil()) {
+			continue
+		}
+		elems[i] = reflect.Indirect(value)
+		present = true
+	}
+	if !present {
+		return reflect.Zero(typ), nil
+	}
+	result := reflect.New(structType).Elem()
+	var key mergeKey
+	if typ.Kind() == reflect.Ptr {
+		key = mergeKey{typ: typ, pointers: pointersKey(values)}
+		if merged, ok := state.merged[key]; ok {
+			return merged, nil
+		}
+		newPtr := reflect.New(structType)
+		result = newPtr.Elem()
+		// stored before merging the fields, so cycles end at the result
+		state.merged[key] = newPtr.Convert(typ)
+	}
+	fieldValues := make([]reflect.Value, len(values))
+	for f := 0; f < structType.NumField(); f++ {
+		field := structType.Field(f)
+		if this.fieldPolicy.SkipField(structType, field) {
+			continue
+		}
+		for i, elem := range elems {
+			fieldValues[i] = reflect.Value{}
+			if elem.IsValid() {
+				fieldValues[i] = elem.Field(f)
+			}
+		}
+		name := strings.ToLower(field.Name)
+		merged, err := this.merge(field.Type, fieldValues, path+"."+name, propertyId+"."+name, true, state)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result.Field(f).Set(merged)
+	}
+	if typ.Kind() != reflect.Ptr {
+		return result, nil
+	}
+	return state.merged[key], nil
+}
+
+// pointersKey returns the addresses of the source pointers as a key, 0 for the sources lacking one.
+func pointersKey(values []reflect.Value) string {
+	key := make([]byte, 0, len(values)*12)
+	for i, value := range values {
+		if i > 0 {
+			key = append(key, ',')
+		}
+		pointer := uint64(0)
+		if value.IsValid() && !value.IsNil() {
+			pointer = uint64(value.Pointer())
+		}
+		key = strconv.AppendUint(key, pointer, 16)
+	}
+	return string(key)
+}
+
+// unionKeys merges maps into the union of their keys.
+func (this *Merger) unionKeys(typ reflect.Type, values []reflect.Value, path, propertyId string, state *mergeState) (reflect.Value, error) {
+	var result reflect.Value
+	elemValues := make([]reflect.Value, len(values))
+	for _, value := range values {
+		if !value.IsValid() || value.IsNil() {
+			continue
+		}
+		if !result.IsValid() {
+			result = reflect.MakeMap(typ)
+		}
+		for _, key := range value.MapKeys() {
+			if result.MapIndex(key).IsValid() {
+				continue
+			}
+			for i, other := range values {
+				elemValues[i] = reflect.Value{}
+				if other.IsValid() {
+					elemValues[i] = other.MapIndex(key)
+				}
+			}
+			elemId := propertyId + "<" + helping.KeyString(key.Interface()) + ">"
+			merged, err := this.merge(typ.Elem(), elemValues, path, elemId, false, state)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			result.SetMapIndex(key, merged)
+		}
+	}
+	if !result.IsValid() {
+		return reflect.Zero(typ), nil
+	}
+	return result, nil
+}
+
+// concatenate merges slices into the concatenation of the clones of their elements.
+func (this *Merger) concatenate(typ reflect.Type, values []reflect.Value, propertyId string, state *mergeState) (reflect.Value, error) {
+	var result reflect.Value
+	for i, value := range values {
+		if !value.IsValid() || value.IsNil() {
+			continue
+		}
+		if !result.IsValid() {
+			result = reflect.MakeSlice(typ, 0, value.Len())
+		}
+		for e := 0; e < value.Len(); e++ {
+			state.provenance[propertyId+"<"+helping.KeyString(result.Len())+">"] = i
+			elem, err := this.clone(typ.Elem(), value.Index(e))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			result = reflect.Append(result, elem)
+		}
+	}
+	if !result.IsValid() {
+		return reflect.Zero(typ), nil
+	}
+	return result, nil
+}
+
+// pick returns the clone of the value of a source, recording the source as its provider.
+func (this *Merger) pick(typ reflect.Type, values []reflect.Value, index int, propertyId string, state *mergeState) (reflect.Value, error) {
+	state.provenance[propertyId] = index
+	return this.clone(typ, values[index])
+}
+
+// clone returns a deep clone of a value, as a value of the given type.
+func (this *Merger) clone(typ reflect.Type, value reflect.Value) (reflect.Value, error) {
+	cloned, err := this.cloner.CloneWithError(value.Interface())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	clone := reflect.ValueOf(cloned)
+	if !clone.IsValid() {
+		return reflect.Zero(typ), nil
+	}
+	return clone, nil
+}
+
+// isStruct returns true for structs and pointers to structs.
+func isStruct(typ reflect.Type) bool {
+	return elemType(typ).Kind() == reflect.Struct
+}
+
+// elemType returns the type a pointer points to, or the type itself.
+func elemType(typ reflect.Type) reflect.Type {
+	if typ.Kind() == reflect.Ptr {
+		return typ.Elem()
+	}
+	return typ
+}
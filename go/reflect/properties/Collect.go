@@ -19,6 +19,7 @@ package properties
 import (
 	"reflect"
 
+	"github.com/saichler/l8reflect/go/reflect/helping"
 	"github.com/saichler/l8types/go/ifs"
 	"github.com/saichler/l8types/go/types/l8reflect"
 )
@@ -52,7 +53,7 @@ func collect(any interface{}, node *l8reflect.L8Node, typeName string,
 	}
 	typ := val.Type()
 	myProperty := NewProperty(node, parent, key, any, r)
-	if typ.Name() == typeName {
+	if helping.CanonicalTypeName(typ.Name()) == helping.CanonicalTypeName(typeName) {
 		id, _ := myProperty.PropertyId()
 		elems[id] = any
 		return
@@ -61,7 +62,7 @@ func collect(any interface{}, node *l8reflect.L8Node, typeName string,
 	if node.Attributes != nil {
 		for _, attr := range node.Attributes {
 			if attr.IsMap {
-				value := val.FieldByName(attr.FieldName)
+				value := containerField(val, attr.FieldName)
 				if value.IsValid() {
 					keys := value.MapKeys()
 					for i := 0; i < len(keys); i++ {
@@ -69,7 +70,7 @@ func collect(any interface{}, node *l8reflect.L8Node, typeName string,
 					}
 				}
 			} else if attr.IsSlice {
-				value := val.FieldByName(attr.FieldName)
+				value := containerField(val, attr.FieldName)
 				if value.IsValid() {
 					for i := 0; i < value.Len(); i++ {
 						collect(value.Index(i).Interface(), attr, typeName, myProperty, i, elems, r)
@@ -84,3 +85,16 @@ func collect(any interface{}, node *l8reflect.L8Node, typeName string,
 		}
 	}
 }
+
+// containerField returns the named slice or map field of a struct value,
+// dereferencing pointer-to-container fields. Returns an invalid value for nil pointers.
+func containerField(val reflect.Value, fieldName string) reflect.Value {
+	value := val.FieldByName(fieldName)
+	if isContainerPtr(value) {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		return value.Elem()
+	}
+	return value
+}
@@ -22,11 +22,30 @@ import (
 
 // getField retrieves a field from a struct value using the cached field index.
 // Falls back to FieldByName if fieldIndex is not set (-1).
+// Non-nil pointers to slices, arrays and maps are dereferenced transparently.
 func (this *Property) getField(structValue reflect.Value) reflect.Value {
+	var field reflect.Value
 	if this.fieldIndex >= 0 {
-		return structValue.Field(this.fieldIndex)
+		field = structValue.Field(this.fieldIndex)
+	} else {
+		field = structValue.FieldByName(this.node.FieldName)
+	}
+	if isContainerPtr(field) && !field.IsNil() {
+		return field.Elem()
+	}
+	return field
+}
+
+// isContainerPtr returns true if the value is a pointer to a slice, array or map.
+func isContainerPtr(value reflect.Value) bool {
+	if value.Kind() != reflect.Ptr {
+		return false
+	}
+	switch value.Type().Elem().Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return true
 	}
-	return structValue.FieldByName(this.node.FieldName)
+	return false
 }
 
 // getMap retrieves values from a map field.
@@ -35,7 +54,11 @@ func (this *Property) getField(structValue reflect.Value) reflect.Value {
 func (this *Property) getMap(parent reflect.Value) []reflect.Value {
 	result := make([]reflect.Value, 0)
 	if this.parent.key != nil {
-		myValue := parent.MapIndex(reflect.ValueOf(this.parent.key))
+		mapKey, err := this.parent.keyFor(parent.Type().Key())
+		if err != nil {
+			return result
+		}
+		myValue := parent.MapIndex(mapKey)
 		if !myValue.IsValid() {
 			return result
 		}
@@ -61,10 +84,17 @@ func (this *Property) getMap(parent reflect.Value) []reflect.Value {
 	return result
 }
 
+// getSlice retrieves values from a slice or fixed-size array field.
+// If a specific index is set in the parent, returns only that element's field value.
+// Otherwise, returns the field value from all elements.
 func (this *Property) getSlice(parent reflect.Value) []reflect.Value {
 	result := make([]reflect.Value, 0)
 	if this.parent.key != nil {
-		myValue := parent.Index(this.parent.key.(int))
+		index := this.parent.key.(int)
+		if index < 0 || index >= parent.Len() {
+			return result
+		}
+		myValue := parent.Index(index)
 		if !myValue.IsValid() {
 			return result
 		}
@@ -117,7 +147,7 @@ func (this *Property) GetValue(any reflect.Value) []reflect.Value {
 		if parent.Kind() == reflect.Map {
 			mapItems := this.getMap(parent)
 			results = append(results, mapItems...)
-		} else if parent.Kind() == reflect.Slice {
+		} else if parent.Kind() == reflect.Slice || parent.Kind() == reflect.Array {
 			sliceItems := this.getSlice(parent)
 			results = append(results, sliceItems...)
 		} else {
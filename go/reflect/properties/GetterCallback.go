@@ -41,7 +41,7 @@ func (this *Property) ForEachValue(any reflect.Value, fn func(reflect.Value) boo
 		switch parent.Kind() {
 		case reflect.Map:
 			return this.forEachMapValue(parent, fn)
-		case reflect.Slice:
+		case reflect.Slice, reflect.Array:
 			return this.forEachSliceValue(parent, fn)
 		default:
 			if parent.IsValid() {
@@ -55,7 +55,11 @@ func (this *Property) ForEachValue(any reflect.Value, fn func(reflect.Value) boo
 // forEachMapValue iterates over map values calling fn for each field value.
 func (this *Property) forEachMapValue(parent reflect.Value, fn func(reflect.Value) bool) bool {
 	if this.parent.key != nil {
-		myValue := parent.MapIndex(reflect.ValueOf(this.parent.key))
+		mapKey, err := this.parent.keyFor(parent.Type().Key())
+		if err != nil {
+			return true
+		}
+		myValue := parent.MapIndex(mapKey)
 		if !myValue.IsValid() {
 			return true
 		}
@@ -84,10 +88,14 @@ func (this *Property) forEachMapValue(parent reflect.Value, fn func(reflect.Valu
 	return true
 }
 
-// forEachSliceValue iterates over slice values calling fn for each field value.
+// forEachSliceValue iterates over slice or array values calling fn for each field value.
 func (this *Property) forEachSliceValue(parent reflect.Value, fn func(reflect.Value) bool) bool {
 	if this.parent.key != nil {
-		myValue := parent.Index(this.parent.key.(int))
+		index := this.parent.key.(int)
+		if index < 0 || index >= parent.Len() {
+			return true
+		}
+		myValue := parent.Index(index)
 		if !myValue.IsValid() {
 			return true
 		}
@@ -0,0 +1,176 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains map key conversion logic for property paths.
+// Keys parsed from a property id carry only their kind, so they are converted
+// to the map's declared key type (enums, named types and struct keys).
+
+package properties
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/saichler/l8reflect/go/reflect/helping"
+	strings2 "github.com/saichler/l8utils/go/utils/strings"
+)
+
+// keyType returns the declared key type of this property's map node, if known.
+func (this *Property) keyType() (reflect.Type, bool) {
+	if this.node == nil || !this.node.IsMap || this.node.KeyTypeName == "" {
+		return nil, false
+	}
+	if this.resources == nil || this.resources.Registry() == nil {
+		return nil, false
+	}
+	info, err := this.resources.Registry().Info(this.node.KeyTypeName)
+	if err != nil {
+		return nil, false
+	}
+	return info.Type(), true
+}
+
+// parseKey parses a key string from a property id.
+// For map nodes the key is converted to the map's declared key type.
+func (this *Property) parseKey(str string) (interface{}, error) {
+	typ, ok := this.keyType()
+	if ok && typ.Kind() == reflect.Struct {
+		v, err := this.structKey(str, typ)
+		if err != nil {
+			return nil, err
+		}
+		return v.Interface(), nil
+	}
+	k, err := strings2.FromString(str, this.resources.Registry())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return k.Interface(), nil
+	}
+	v, err := this.convertKey(k, typ)
+	if err != nil {
+		return nil, err
+	}
+	return v.Interface(), nil
+}
+
+// keyFor returns this property's key as a value of the given map key type.
+func (this *Property) keyFor(typ reflect.Type) (reflect.Value, error) {
+	if this.key == nil {
+		return reflect.Value{}, errors.New("property has no key")
+	}
+	value := reflect.ValueOf(this.key)
+	if value.Type() == typ {
+		return value, nil
+	}
+	if typ.Kind() == reflect.Struct && value.Kind() == reflect.String {
+		return this.structKey(value.String(), typ)
+	}
+	return this.convertKey(value, typ)
+}
+
+// structKey decodes a "::" separated key string into a struct key of the given type.
+func (this *Property) structKey(str string, typ reflect.Type) (reflect.Value, error) {
+	tokens := helping.StructKeyFields(str)
+	key := reflect.New(typ).Elem()
+	index := 0
+	for i := 0; i < typ.NumField(); i++ {
+		if helping.IgnoreName(typ.Field(i).Name) {
+			continue
+		}
+		if index >= len(tokens) {
+			return reflect.Value{}, errors.New("missing fields in key " + str + " for type " + typ.Name())
+		}
+		v, err := strings2.FromString(tokens[index], this.resources.Registry())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v, err = this.convertKey(v, typ.Field(i).Type)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		key.Field(i).Set(v)
+		index++
+	}
+	if index != len(tokens) {
+		return reflect.Value{}, errors.New("too many fields in key " + str + " for type " + typ.Name())
+	}
+	return key, nil
+}
+
+// convertKey converts a scalar key value to the given type.
+// Handles named types (e.g. enums), numeric kind differences, enum names and numeric strings.
+func (this *Property) convertKey(value reflect.Value, typ reflect.Type) (reflect.Value, error) {
+	if value.Type() == typ {
+		return value, nil
+	}
+	if value.Kind() == reflect.String && IsNumeric(typ.Kind()) {
+		parsed, err := this.parseNumericKey(value.String(), typ)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		value = parsed
+	} else if value.Kind() != typ.Kind() {
+		value = ConvertValue(reflect.Zero(typ), value)
+	}
+	if value.Type() != typ {
+		if !value.Type().ConvertibleTo(typ) || value.Kind() != typ.Kind() {
+			return reflect.Value{}, errors.New("cannot convert key of type " + value.Type().String() + " to " + typ.String())
+		}
+		value = value.Convert(typ)
+	}
+	return value, nil
+}
+
+// parseNumericKey parses a string key into a numeric value of the given type.
+// Strings that are not numbers are resolved as enum names via the registry.
+func (this *Property) parseNumericKey(str string, typ reflect.Type) (reflect.Value, error) {
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			if typ.Kind() != reflect.Int32 {
+				return reflect.Value{}, err
+			}
+			i = int64(this.resources.Registry().Enum(str))
+			if i == 0 && !isZeroEnumName(str, typ) {
+				return reflect.Value{}, errors.New("unknown enum value " + str + " for " + typ.String())
+			}
+		}
+		return reflect.ValueOf(i).Convert(typ), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(str, 10, 64)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(u).Convert(typ), nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(f).Convert(typ), nil
+	}
+	return reflect.Value{}, errors.New("cannot parse key " + str + " as " + typ.String())
+}
+
+// isZeroEnumName reports whether str is the name of the zero value of an enum type.
+// The registry resolves unknown names to 0, so a 0 is only accepted when the
+// enum's own String() names its zero value str.
+func isZeroEnumName(str string, typ reflect.Type) bool {
+	stringer, ok := reflect.Zero(typ).Interface().(fmt.Stringer)
+	return ok && stringer.String() == str
+}
@@ -92,7 +92,10 @@ func (this *Property) mapSet(myMapValue reflect.Value, newMapValue reflect.Value
 		return myMapValue.Interface(), nil
 	}
 
-	mapKey := reflect.ValueOf(this.key)
+	mapKey, err := this.keyFor(myMapValue.Type().Key())
+	if err != nil {
+		return nil, err
+	}
 	oKeyValue := myMapValue.MapIndex(mapKey)
 	//in this case, the newMapValue isn't a map, it is a value
 	//this.value = newMapValue.Interface()
@@ -113,43 +113,28 @@ func (this *Property) Resources() ifs.IResources {
 	return this.resources
 }
 
-// setKeyValue extracts and sets the key from a property path.
-// Returns the remaining prefix path after extracting the key.
+// setKeyValue extracts and sets the key of the last attribute of a property path.
+// Returns the path of the parent property.
 func (this *Property) setKeyValue(propertyId string) (string, error) {
-	id := propertyId
-	dIndex := strings.LastIndex(propertyId, ".")
-	if dIndex == -1 {
+	if propertyId == "" {
 		return "", nil
 	}
-	beIndex := strings.LastIndex(propertyId, ">")
-	if beIndex == -1 {
-		return "", nil
-	}
-
-	if dIndex > beIndex {
-		prefix := propertyId[0:dIndex]
-		return prefix, nil
-	}
-
-	bsIndex := strings.LastIndex(propertyId, "<")
-	if dIndex > bsIndex {
-		id = propertyId[:bsIndex]
-		dIndex = strings.LastIndex(id, ".")
+	segments, err := helping.PathSegments(propertyId)
+	if err != nil {
+		return "", err
 	}
-	prefix := propertyId[0:dIndex]
-	suffix := propertyId[dIndex+1:]
-	bbIndex := strings.LastIndex(suffix, "<")
-	if bbIndex == -1 {
-		return prefix, nil
+	if len(segments) < 2 {
+		return "", nil
 	}
-
-	v := suffix[bbIndex+1 : len(suffix)-1]
-	k, e := strings2.FromString(v, this.resources.Registry())
-	if e != nil {
-		return "", e
+	last := segments[len(segments)-1]
+	if last.HasKey {
+		k, e := this.parseKey(last.Key)
+		if e != nil {
+			return "", e
+		}
+		this.key = k
 	}
-	this.key = k.Interface()
-	return prefix, nil
+	return helping.JoinPathSegments(segments[:len(segments)-1]), nil
 }
 
 // IsString returns true if this property holds a string value.
@@ -162,13 +147,14 @@ func (this *Property) IsString() bool {
 
 // PropertyId generates and caches the unique path string for this property.
 // Format: "typename.field<key>.subfield<key>"
+// Struct map keys are encoded as their field values separated by "::".
 func (this *Property) PropertyId() (string, error) {
 	if this.id != "" {
 		return this.id, nil
 	}
 	buff := strings2.New()
 	if this.parent == nil {
-		buff.Add(strings.ToLower(this.node.TypeName))
+		buff.Add(strings.ToLower(helping.CanonicalTypeName(this.node.TypeName)))
 		buff.Add(this.node.CachedKey)
 	} else {
 		pi, err := this.parent.PropertyId()
@@ -180,10 +166,8 @@ func (this *Property) PropertyId() (string, error) {
 		buff.Add(strings.ToLower(this.node.FieldName))
 	}
 	if this.key != nil {
-		keyStr := strings2.New()
-		keyStr.TypesPrefix = true
 		buff.Add("<")
-		buff.Add(keyStr.StringOf(this.key))
+		buff.Add(helping.KeyString(this.key))
 		buff.Add(">")
 	}
 	this.id = buff.String()
@@ -199,7 +183,7 @@ func (this *Property) PropertyDisplayId() string {
 	buff := strings2.New()
 	if this.parent == nil {
 		buff.Add("[")
-		buff.Add(this.node.TypeName)
+		buff.Add(helping.CanonicalTypeName(this.node.TypeName))
 		buff.Add(this.node.CachedKey)
 		buff.Add("]")
 		return buff.String()
@@ -271,11 +255,13 @@ func newProperty(node *l8reflect.L8Node, propertyPath string, resources ifs.IRes
 		pi.isLeaf = false
 		// Pre-compute field index to avoid FieldByName linear search
 		property.fieldIndex = property.computeFieldIndex()
-	} else {
-		index1 := strings.Index(propertyPath, "<")
-		index2 := strings.Index(propertyPath, ">")
-		if index1 != -1 && index2 != -1 && index2 > index1 {
-			k, e := strings2.FromString(propertyPath[index1+1:index2], property.resources.Registry())
+	} else if propertyPath != "" {
+		segments, err := helping.PathSegments(propertyPath)
+		if err != nil {
+			return nil, err
+		}
+		if segments[0].HasKey {
+			k, e := strings2.FromString(segments[0].Key, property.resources.Registry())
 			if e != nil {
 				return nil, e
 			}
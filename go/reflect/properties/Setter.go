@@ -64,11 +64,19 @@ func (this *Property) Set(any interface{}, value interface{}) (interface{}, inte
 	//Special case for setting a value to the map
 	if this.node.IsMap && parentValue.Kind() == reflect.Map {
 		if this.IsLeaf() {
-			parentValue.SetMapIndex(reflect.ValueOf(this.key), reflect.ValueOf(this.value))
+			mapKey, err := this.keyFor(parentValue.Type().Key())
+			if err != nil {
+				return nil, nil, err
+			}
+			parentValue.SetMapIndex(mapKey, reflect.ValueOf(this.value))
 		}
 		return this.value, any, nil
 	} else if parentValue.Kind() == reflect.Map {
-		parentValue = parentValue.MapIndex(reflect.ValueOf(this.key))
+		mapKey, err := this.keyFor(parentValue.Type().Key())
+		if err != nil {
+			return nil, nil, err
+		}
+		parentValue = parentValue.MapIndex(mapKey)
 		// If the map entry doesn't exist, parentValue will be a zero Value.
 		// We need to check this and return an error, as we cannot navigate further.
 		if !parentValue.IsValid() {
@@ -94,9 +102,19 @@ func (this *Property) Set(any interface{}, value interface{}) (interface{}, inte
 		return nil, nil, err
 	}
 	typ := info.Type()
+	if isContainerPtr(myValue) {
+		elem, done := this.derefContainer(myValue, value)
+		if done {
+			return value, any, nil
+		}
+		myValue = elem
+	}
 	if this.node.IsMap {
 		v, e := this.mapSet(myValue, reflect.ValueOf(value))
 		return v, any, e
+	} else if this.node.IsSlice && myValue.Kind() == reflect.Array {
+		v, e := this.arraySet(myValue, reflect.ValueOf(value))
+		return v, any, e
 	} else if this.node.IsSlice && this.node.TypeName == "L8TimeSeriesPoint" {
 		v, e := this.timeSeriesAppend(myValue, reflect.ValueOf(value))
 		return v, any, e
@@ -175,6 +193,28 @@ func (this *Property) Set(any interface{}, value interface{}) (interface{}, inte
 	}
 }
 
+// derefContainer resolves a pointer-to-slice or pointer-to-map field.
+// Setting the whole container to nil clears the pointer (container absent) and
+// a value of the pointer type replaces it; both return done=true.
+// Otherwise the pointer is allocated on demand and its element is returned.
+func (this *Property) derefContainer(myValue reflect.Value, value interface{}) (reflect.Value, bool) {
+	if this.key == nil {
+		if value == nil {
+			myValue.Set(reflect.Zero(myValue.Type()))
+			return myValue, true
+		}
+		v := reflect.ValueOf(value)
+		if v.Type() == myValue.Type() {
+			myValue.Set(v)
+			return myValue, true
+		}
+	}
+	if myValue.IsNil() {
+		myValue.Set(reflect.New(myValue.Type().Elem()))
+	}
+	return myValue.Elem(), false
+}
+
 func (this *Property) SetPrimaryKey(node *l8reflect.L8Node, any interface{}, anyKey interface{}) {
 	if anyKey == nil {
 		return
@@ -12,7 +12,8 @@
 // limitations under the License.
 
 // This file contains slice-specific setter logic for property operations.
-// Handles slice creation, resizing, element insertion, and deletion through property paths.
+// Handles slice creation, resizing, element insertion, and deletion through property paths,
+// as well as element updates of fixed-size arrays.
 
 package properties
 
@@ -172,3 +173,62 @@ func (this *Property) sliceSet(myValue reflect.Value, newSliceValue reflect.Valu
 
 	return oIndexValue.Interface(), err
 }
+
+// arraySet handles setting values within fixed-size array fields.
+// Supports replacing the entire array and setting individual elements by index.
+// Arrays cannot grow or shrink, so out of range indices are rejected and
+// deleted entries are reset to their zero value.
+func (this *Property) arraySet(myValue reflect.Value, newValue reflect.Value) (interface{}, error) {
+	//Replace the entire array
+	if this.key == nil {
+		if !newValue.IsValid() {
+			myValue.Set(reflect.Zero(myValue.Type()))
+			return nil, nil
+		}
+		if newValue.Type() != myValue.Type() {
+			pid, _ := this.PropertyId()
+			return nil, errors.New("invalid array type " + newValue.Type().String() + " for property " + pid)
+		}
+		myValue.Set(newValue)
+		return myValue.Interface(), nil
+	}
+
+	index := this.key.(int)
+	if index < 0 || index >= myValue.Len() {
+		pid, _ := this.PropertyId()
+		return nil, errors.New("index out of range for array property " + pid)
+	}
+	oIndexValue := myValue.Index(index)
+
+	//Array elements cannot be removed, so reset the deleted element
+	if newValue.Kind() == reflect.String && newValue.String() == ifs.Deleted_Entry {
+		oIndexValue.Set(reflect.Zero(oIndexValue.Type()))
+		return myValue.Interface(), nil
+	}
+
+	//If this is not a leaf property
+	//We need to continue drilling down
+	if this.node.IsStruct && !this.IsLeaf() {
+		if oIndexValue.IsNil() {
+			oIndexValue.Set(reflect.New(oIndexValue.Type().Elem()))
+		}
+		return oIndexValue.Interface(), nil
+	}
+
+	if !newValue.IsValid() {
+		oIndexValue.Set(reflect.Zero(oIndexValue.Type()))
+		return nil, nil
+	}
+
+	//The new value may be the entire array or just the element
+	if newValue.Type() == myValue.Type() {
+		newValue = newValue.Index(index)
+	} else if newValue.Kind() != oIndexValue.Kind() {
+		newValue = ConvertValue(oIndexValue, newValue)
+	}
+	if newValue.Type() != oIndexValue.Type() && newValue.Type().ConvertibleTo(oIndexValue.Type()) {
+		newValue = newValue.Convert(oIndexValue.Type())
+	}
+	oIndexValue.Set(newValue)
+	return oIndexValue.Interface(), nil
+}
@@ -0,0 +1,70 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the array comparator for detecting changes in fixed-size array fields.
+// Arrays never change size, so only element modifications are detected.
+
+package updating
+
+import (
+	"reflect"
+
+	"github.com/saichler/l8reflect/go/reflect/properties"
+	"github.com/saichler/l8types/go/types/l8reflect"
+)
+
+// arrayUpdate compares and updates fixed-size array values element by element.
+// An all-zero new array is treated as "not set" unless nilIsValid is true.
+func arrayUpdate(instance *properties.Property, node *l8reflect.L8Node, oldValue, newValue reflect.Value, updates *Updater) error {
+	if newValue.IsZero() && !updates.nilIsValid {
+		return nil
+	}
+
+	// Byte arrays are treated as atomic values, same as byte slices.
+	if oldValue.Type().Elem().Kind() == reflect.Uint8 {
+		if !updates.deepEqual.Equal(oldValue.Interface(), newValue.Interface()) {
+			updates.addUpdate(instance, oldValue.Interface(), newValue.Interface())
+			if !updates.dryRun {
+				oldValue.Set(newValue)
+			}
+		}
+		return nil
+	}
+
+	for i := 0; i < oldValue.Len(); i++ {
+		oldIndexValue := oldValue.Index(i)
+		newIndexValue := newValue.Index(i)
+		if updates.deepEqual.Equal(oldIndexValue.Interface(), newIndexValue.Interface()) {
+			continue
+		}
+		subProperty := properties.NewProperty(node, instance.Parent().(*properties.Property), i,
+			newIndexValue.Interface(), updates.resources)
+		if node.IsStruct && oldIndexValue.Kind() == reflect.Ptr {
+			if newIndexValue.IsNil() && !updates.nilIsValid {
+				continue
+			}
+			if !oldIndexValue.IsNil() && !newIndexValue.IsNil() {
+				err := structUpdate(subProperty, node, oldIndexValue.Elem(), newIndexValue.Elem(), updates)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		updates.addUpdate(subProperty, oldIndexValue.Interface(), newIndexValue.Interface())
+		if !updates.dryRun {
+			oldIndexValue.Set(newIndexValue)
+		}
+	}
+	return nil
+}
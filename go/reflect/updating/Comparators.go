@@ -28,7 +28,8 @@ import (
 // comparators maps reflect.Kind to the appropriate comparison function for that type.
 var comparators map[reflect.Kind]func(*properties.Property, *l8reflect.L8Node, reflect.Value, reflect.Value, *Updater) error
 
-// deepEqual is used for comparing complex values like structs and slices.
+// deepEqual is the default comparer of complex values like structs and slices,
+// shared by all Updaters that do not set their own field policy.
 var deepEqual = cloning.NewDeepEqual()
 
 func init() {
@@ -58,6 +59,8 @@ func init() {
 
 	comparators[reflect.Slice] = sliceUpdate
 
+	comparators[reflect.Array] = arrayUpdate
+
 	comparators[reflect.Map] = mapUpdate
 }
 
@@ -110,7 +113,7 @@ func boolUpdate(instance *properties.Property, node *l8reflect.L8Node, oldValue,
 
 // floatUpdate compares and updates floating-point values.
 func floatUpdate(instance *properties.Property, node *l8reflect.L8Node, oldValue, newValue reflect.Value, updates *Updater) error {
-	if oldValue.Float() != newValue.Float() && (newValue.Float() != 0 || updates.nilIsValid) {
+	if !updates.deepEqual.FloatEqual(oldValue.Float(), newValue.Float()) && (newValue.Float() != 0 || updates.nilIsValid) {
 		updates.addUpdate(instance, oldValue.Interface(), newValue.Interface())
 		if !updates.dryRun {
 			oldValue.Set(newValue)
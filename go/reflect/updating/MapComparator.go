@@ -30,6 +30,9 @@ func mapUpdate(instance *properties.Property, node *l8reflect.L8Node, oldValue,
 	if oldValue.IsNil() && newValue.IsNil() {
 		return nil
 	}
+	if (oldValue.IsNil() || newValue.IsNil()) && updates.nilAsEmpty() && oldValue.Len() == 0 && newValue.Len() == 0 {
+		return nil
+	}
 	if oldValue.IsNil() && !newValue.IsNil() {
 		updates.addUpdate(instance, nil, newValue.Interface())
 		if !updates.dryRun {
@@ -70,7 +73,7 @@ func mapUpdate(instance *properties.Property, node *l8reflect.L8Node, oldValue,
 		}
 
 		if !node.IsStruct {
-			if deepEqual.Equal(oldKeyValue.Interface(), newKeyValue.Interface()) {
+			if updates.deepEqual.Equal(oldKeyValue.Interface(), newKeyValue.Interface()) {
 				continue
 			}
 			subProperty := properties.NewProperty(node, instance.Parent().(*properties.Property), key.Interface(), newKeyValue.Interface(), updates.resources)
@@ -79,7 +82,7 @@ func mapUpdate(instance *properties.Property, node *l8reflect.L8Node, oldValue,
 				oldValue.SetMapIndex(key, newKeyValue)
 			}
 		} else if oldKeyValue.IsValid() && newKeyValue.IsValid() {
-			if deepEqual.Equal(oldKeyValue.Interface(), newKeyValue.Interface()) {
+			if updates.deepEqual.Equal(oldKeyValue.Interface(), newKeyValue.Interface()) {
 				continue
 			}
 			subProperty := properties.NewProperty(node, instance.Parent().(*properties.Property), key.Interface(), newKeyValue.Interface(), updates.resources)
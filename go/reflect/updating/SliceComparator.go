@@ -31,6 +31,9 @@ func sliceUpdate(instance *properties.Property, node *l8reflect.L8Node, oldValue
 	if oldValue.IsNil() && newValue.IsNil() {
 		return nil
 	}
+	if (oldValue.IsNil() || newValue.IsNil()) && updates.nilAsEmpty() && oldValue.Len() == 0 && newValue.Len() == 0 {
+		return nil
+	}
 	if oldValue.IsNil() && !newValue.IsNil() {
 		updates.addUpdate(instance, nil, newValue.Interface())
 		if !updates.dryRun {
@@ -68,7 +71,7 @@ func sliceUpdate(instance *properties.Property, node *l8reflect.L8Node, oldValue
 		oldIndexValue := oldValue.Index(i)
 		newIndexValue := newValue.Index(i)
 		if !node.IsStruct {
-			if oldIndexValue.IsValid() && deepEqual.Equal(oldIndexValue.Interface(), newIndexValue.Interface()) {
+			if oldIndexValue.IsValid() && updates.deepEqual.Equal(oldIndexValue.Interface(), newIndexValue.Interface()) {
 				continue
 			}
 			subProperty := properties.NewProperty(node, instance.Parent().(*properties.Property), i,
@@ -85,7 +88,7 @@ func sliceUpdate(instance *properties.Property, node *l8reflect.L8Node, oldValue
 				oldIndexValue.Set(newIndexValue)
 			}
 		} else if oldIndexValue.IsValid() && newIndexValue.IsValid() {
-			if deepEqual.Equal(oldIndexValue.Interface(), newIndexValue.Interface()) {
+			if updates.deepEqual.Equal(oldIndexValue.Interface(), newIndexValue.Interface()) {
 				continue
 			}
 			subProperty := properties.NewProperty(node, instance.Parent().(*properties.Property),
@@ -47,6 +47,18 @@ func ptrUpdate(property *properties.Property, node *l8reflect.L8Node, oldValue,
 	return update(property, node, oldValue.Elem(), newValue.Elem(), updates)
 }
 
+// skipField returns true if the field policy of the updater skips the named field.
+func (this *Updater) skipField(structType reflect.Type, fieldName string) bool {
+	if this.fieldPolicy == nil {
+		return false
+	}
+	field, ok := structType.FieldByName(fieldName)
+	if !ok {
+		return false
+	}
+	return this.fieldPolicy.SkipField(structType, field)
+}
+
 // structUpdate compares and updates struct values by recursively comparing each field.
 func structUpdate(property *properties.Property, node *l8reflect.L8Node, oldValue, newValue reflect.Value, updates *Updater) error {
 	if !oldValue.IsValid() && newValue.IsValid() {
@@ -74,6 +86,9 @@ func structUpdate(property *properties.Property, node *l8reflect.L8Node, oldValu
 		return errors.New("Mismatch type, old=" + oldValue.Type().Name() + ", new=" + newValue.Type().Name())
 	}
 	for _, attr := range node.Attributes {
+		if updates.skipField(oldValue.Type(), attr.FieldName) {
+			continue
+		}
 		oldFldValue := oldValue.FieldByName(attr.FieldName)
 		newFldValue := newValue.FieldByName(attr.FieldName)
 
@@ -85,7 +100,7 @@ func structUpdate(property *properties.Property, node *l8reflect.L8Node, oldValu
 				continue
 			}
 			if oldFldValue.IsValid() && !oldFldValue.IsNil() &&
-				deepEqual.Equal(oldFldValue.Interface(), newFldValue.Interface()) {
+				updates.deepEqual.Equal(oldFldValue.Interface(), newFldValue.Interface()) {
 				continue
 			}
 			subInstance := properties.NewProperty(attr, property, nil, oldFldValue, updates.resources)
@@ -97,6 +112,9 @@ func structUpdate(property *properties.Property, node *l8reflect.L8Node, oldValu
 		}
 
 		subInstance := properties.NewProperty(attr, property, nil, oldFldValue, updates.resources)
+		if updates.ignored(subInstance) {
+			continue
+		}
 		err := update(subInstance, attr, oldFldValue, newFldValue, updates)
 		if err != nil {
 			return err
@@ -21,6 +21,8 @@ import (
 	"errors"
 	"reflect"
 
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+	"github.com/saichler/l8reflect/go/reflect/helping"
 	"github.com/saichler/l8reflect/go/reflect/properties"
 	"github.com/saichler/l8types/go/ifs"
 	"github.com/saichler/l8types/go/types/l8reflect"
@@ -39,6 +41,14 @@ type Updater struct {
 	newItemIsFull bool
 	// dryRun when true skips mutation of the old instance, only recording changes
 	dryRun bool
+	// deepEqual compares complex values like structs and slices
+	deepEqual *cloning.DeepEqual
+	// fieldPolicy when set skips matching struct fields during the update
+	fieldPolicy helping.FieldPolicy
+	// equalOptions when set relaxes the change detection, see cloning.EqualOptions
+	equalOptions *cloning.EqualOptions
+	// limits when set bounds the new instances, see cloning.Limits
+	limits *cloning.Limits
 }
 
 // NewUpdater creates a new Updater with the given configuration.
@@ -49,9 +59,58 @@ func NewUpdater(resources ifs.IResources, isNilValid, newItemIsFull bool) *Updat
 	upd.resources = resources
 	upd.nilIsValid = isNilValid
 	upd.newItemIsFull = newItemIsFull
+	upd.deepEqual = deepEqual
 	return upd
 }
 
+// SetFieldPolicy sets the policies deciding which struct fields are ignored by the update,
+// both when walking the node tree and when comparing nested values.
+// The default rule (see helping.IgnoreName) is always applied in addition to the given policies.
+func (this *Updater) SetFieldPolicy(policies ...helping.FieldPolicy) {
+	this.fieldPolicy = helping.NewFieldPolicy(policies...)
+	this.deepEqual = cloning.NewDeepEqual()
+	this.deepEqual.SetFieldPolicy(policies...)
+	this.deepEqual.SetOptions(this.equalOptions)
+}
+
+// SetEqualOptions relaxes the change detection with the same semantics as DeepEqual:
+// floats within the tolerance are unchanged, NaN may equal NaN, nil and empty slices
+// and maps may be equal, and struct fields matching the ignored paths are not updated.
+// Returns an error if an ignored path is malformed.
+func (this *Updater) SetEqualOptions(options *cloning.EqualOptions) error {
+	deepEqual := cloning.NewDeepEqual()
+	if this.fieldPolicy != nil {
+		deepEqual.SetFieldPolicy(this.fieldPolicy)
+	}
+	err := deepEqual.SetOptions(options)
+	if err != nil {
+		return err
+	}
+	this.deepEqual = deepEqual
+	this.equalOptions = options
+	return nil
+}
+
+// SetLimits sets the limits of the new instances given to Update and DryUpdate, nil for none.
+// A new instance exceeding them is rejected before anything is updated.
+func (this *Updater) SetLimits(limits *cloning.Limits) {
+	this.limits = limits
+}
+
+// nilAsEmpty returns true if nil and empty slices and maps are considered equal.
+func (this *Updater) nilAsEmpty() bool {
+	return this.equalOptions != nil && this.equalOptions.NilAsEmpty
+}
+
+// ignored returns true if the property matches one of the ignored paths of the equal options.
+func (this *Updater) ignored(prop *properties.Property) bool {
+	if this.equalOptions == nil || len(this.equalOptions.IgnorePaths) == 0 {
+		return false
+	}
+	propertyId, err := prop.PropertyId()
+	return err == nil && this.deepEqual.IgnoredPath(propertyId)
+}
+
 // Changes returns the list of changes detected during the update operation.
 func (this *Updater) Changes() []*Change {
 	return this.changes
@@ -66,13 +125,20 @@ func (this *Updater) DryUpdate(old, new interface{}) error {
 }
 
 // Update compares old and new instances, applies changes to old, and records all modifications.
-// Returns an error if either value is nil or if type comparison fails.
+// Returns an error if either value is nil or if type comparison fails,
+// or a *cloning.LimitError if new exceeds the limits set by SetLimits.
 func (this *Updater) Update(old, new interface{}) error {
 	oldValue := reflect.ValueOf(old)
 	newValue := reflect.ValueOf(new)
 	if !oldValue.IsValid() || !newValue.IsValid() {
 		return errors.New("either old or new are nil or invalid")
 	}
+	if this.limits != nil {
+		err := this.limits.Check(new)
+		if err != nil {
+			return err
+		}
+	}
 	if oldValue.Kind() == reflect.Ptr {
 		oldValue = oldValue.Elem()
 		newValue = newValue.Elem()
@@ -0,0 +1,190 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+	"github.com/saichler/l8reflect/go/reflect/introspecting"
+	"github.com/saichler/l8reflect/go/reflect/properties"
+	"github.com/saichler/l8reflect/go/reflect/updating"
+	"github.com/saichler/l8types/go/ifs"
+	"github.com/saichler/l8utils/go/utils/registry"
+	"github.com/saichler/l8utils/go/utils/resources"
+)
+
+type ArrayModel struct {
+	Id     string
+	Digest [4]byte
+	Counts [3]int32
+	Slots  [2]*ArraySlot
+}
+
+type ArraySlot struct {
+	Name string
+	Size int32
+}
+
+// newLocalResources creates resources for models declared in this test package.
+func newLocalResources(primaryKeys map[interface{}]string) ifs.IResources {
+	res := resources.NewResources(log)
+	res.Set(registry.NewRegistry())
+	in := introspecting.NewIntrospect(res.Registry())
+	res.Set(in)
+	for model, key := range primaryKeys {
+		in.Decorators().AddPrimaryKeyDecorator(model, key)
+	}
+	return res
+}
+
+func newArrayModel() *ArrayModel {
+	return &ArrayModel{
+		Id:     "a1",
+		Digest: [4]byte{1, 2, 3, 4},
+		Counts: [3]int32{10, 20, 30},
+		Slots:  [2]*ArraySlot{{Name: "s0", Size: 1}, {Name: "s1", Size: 2}},
+	}
+}
+
+func TestArrayIntrospect(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{&ArrayModel{}: "Id"})
+	node, ok := res.Introspector().Node("arraymodel.counts")
+	if !ok {
+		log.Fail(t, "Expected a node for the array field")
+		return
+	}
+	if !node.IsSlice {
+		log.Fail(t, "Expected the array node to be an indexed container")
+		return
+	}
+	_, ok = res.Introspector().Node("arraymodel.slots.name")
+	if !ok {
+		log.Fail(t, "Expected array of struct pointers to be inspected")
+		return
+	}
+}
+
+func TestArrayPropertyGetSet(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{&ArrayModel{}: "Id"})
+	model := newArrayModel()
+
+	prop, err := properties.PropertyOf("arraymodel.slots<{2}1>.name", res)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	v, err := prop.Get(model)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if v != "s1" {
+		log.Fail(t, "Expected s1 but got ", v)
+		return
+	}
+
+	_, _, err = prop.Set(model, "changed")
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if model.Slots[1].Name != "changed" {
+		log.Fail(t, "Expected slot name to be changed but got ", model.Slots[1].Name)
+		return
+	}
+
+	prop, err = properties.PropertyOf("arraymodel.counts<{2}2>", res)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	_, _, err = prop.Set(model, int32(99))
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if model.Counts[2] != 99 {
+		log.Fail(t, "Expected count to be 99 but got ", model.Counts[2])
+		return
+	}
+
+	prop, err = properties.PropertyOf("arraymodel.counts<{2}3>", res)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	_, _, err = prop.Set(model, int32(1))
+	if err == nil {
+		log.Fail(t, "Expected an error for an out of range index")
+		return
+	}
+}
+
+func TestArrayDeepEqual(t *testing.T) {
+	aside := newArrayModel()
+	zside := cloning.NewCloner().Clone(aside).(*ArrayModel)
+	de := cloning.NewDeepEqual()
+	if !de.Equal(aside, zside) {
+		log.Fail(t, "Expected clone to be equal")
+		return
+	}
+	zside.Digest[3] = 9
+	if de.Equal(aside, zside) {
+		log.Fail(t, "Expected a byte array difference to be detected")
+		return
+	}
+}
+
+func TestArrayUpdater(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{&ArrayModel{}: "Id"})
+	aside := newArrayModel()
+	zside := cloning.NewCloner().Clone(aside).(*ArrayModel)
+	yside := cloning.NewCloner().Clone(aside).(*ArrayModel)
+	zside.Counts[1] = 21
+	zside.Slots[0].Size = 5
+	zside.Digest = [4]byte{4, 3, 2, 1}
+
+	upd := updating.NewUpdater(res, false, false)
+	err := upd.Update(aside, zside)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if len(upd.Changes()) != 3 {
+		log.Fail(t, "Expected 3 changes but got ", len(upd.Changes()))
+		return
+	}
+	if !cloning.NewDeepEqual().Equal(aside, zside) {
+		log.Fail(t, "Expected old to be updated")
+		return
+	}
+
+	for _, change := range upd.Changes() {
+		prop, err := properties.PropertyOf(change.PropertyId(), res)
+		if err != nil {
+			log.Fail(t, err.Error())
+			return
+		}
+		_, _, err = prop.Set(yside, change.NewValue())
+		if err != nil {
+			log.Fail(t, err.Error())
+			return
+		}
+	}
+	if !cloning.NewDeepEqual().Equal(yside, zside) {
+		log.Fail(t, "Expected changes to apply on a third copy")
+		return
+	}
+}
@@ -0,0 +1,99 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+)
+
+type AliasEntry struct {
+	Name string
+}
+
+type AliasCache struct {
+	ByName    map[string]*AliasEntry
+	Primary   map[string]*AliasEntry
+	Entries   []*AliasEntry
+	Recent    []*AliasEntry
+	Head      []*AliasEntry
+	Current   *AliasEntry
+	Preferred *AliasEntry
+}
+
+func newAliasCache() *AliasCache {
+	entry := &AliasEntry{Name: "e1"}
+	byName := map[string]*AliasEntry{"e1": entry}
+	entries := []*AliasEntry{entry, {Name: "e2"}}
+	return &AliasCache{
+		ByName:    byName,
+		Primary:   byName,
+		Entries:   entries,
+		Recent:    entries,
+		Head:      entries[:1],
+		Current:   entry,
+		Preferred: entry,
+	}
+}
+
+func samePointer(a, b interface{}) bool {
+	return reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+}
+
+func TestCloneAliasingDefault(t *testing.T) {
+	orig := newAliasCache()
+	clone := cloning.NewCloner().Clone(orig).(*AliasCache)
+	if clone.Current != clone.Preferred || clone.Current == orig.Current {
+		log.Fail(t, "Expected shared pointers to be cloned once")
+		return
+	}
+	if samePointer(clone.ByName, clone.Primary) {
+		log.Fail(t, "Expected maps to be cloned independently by default")
+		return
+	}
+	if samePointer(clone.Entries, clone.Recent) {
+		log.Fail(t, "Expected slices to be cloned independently by default")
+		return
+	}
+}
+
+func TestCloneAliasingPreserved(t *testing.T) {
+	orig := newAliasCache()
+	cloner := cloning.NewCloner()
+	cloner.SetPreserveAliasing(true)
+	clone := cloner.Clone(orig).(*AliasCache)
+	if !samePointer(clone.ByName, clone.Primary) || samePointer(clone.ByName, orig.ByName) {
+		log.Fail(t, "Expected shared map to be cloned once")
+		return
+	}
+	if !samePointer(clone.Entries, clone.Recent) || samePointer(clone.Entries, orig.Entries) {
+		log.Fail(t, "Expected shared slice to be cloned once")
+		return
+	}
+	if clone.Entries[0] != clone.Current || clone.ByName["e1"] != clone.Current {
+		log.Fail(t, "Expected shared pointer inside containers to be cloned once")
+		return
+	}
+	if len(clone.Head) != 1 || clone.Head[0] != clone.Current {
+		log.Fail(t, "Expected sub slice to keep its length and elements")
+		return
+	}
+	clone.Primary["e3"] = &AliasEntry{Name: "e3"}
+	if len(clone.ByName) != 2 || len(orig.ByName) != 1 {
+		log.Fail(t, "Expected clone map sharing not to affect the original")
+		return
+	}
+}
@@ -0,0 +1,116 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"reflect"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+)
+
+type HookSession struct {
+	Name  string
+	Hits  int32
+	Token string
+}
+
+// DeepClone copies the session but resets its hit counter and token.
+func (this *HookSession) DeepClone() interface{} {
+	return &HookSession{Name: this.Name}
+}
+
+type HookPool struct {
+	Lock    *sync.Mutex
+	Pattern *regexp.Regexp
+}
+
+type HookModel struct {
+	Id       string
+	Pool     *HookPool
+	Sessions map[string][]*HookSession
+	Current  *HookSession
+}
+
+func newHookModel() *HookModel {
+	session := &HookSession{Name: "s1", Hits: 5, Token: "secret"}
+	return &HookModel{
+		Id:       "h1",
+		Pool:     &HookPool{Lock: &sync.Mutex{}, Pattern: regexp.MustCompile("^eth[0-9]+$")},
+		Sessions: map[string][]*HookSession{"a": {session}},
+		Current:  session,
+	}
+}
+
+func TestCloneDeepClonerInterface(t *testing.T) {
+	orig := newHookModel()
+	clone := cloning.NewCloner().Clone(orig).(*HookModel)
+	session := clone.Sessions["a"][0]
+	if session == orig.Current || session.Name != "s1" || session.Hits != 0 || session.Token != "" {
+		log.Fail(t, "Expected nested session to be cloned by its DeepClone method")
+		return
+	}
+	if clone.Current != session {
+		log.Fail(t, "Expected shared session to be cloned once")
+		return
+	}
+}
+
+func TestCloneRegisterCloneFunc(t *testing.T) {
+	orig := newHookModel()
+	orig.Pool.Lock.Lock()
+	cloner := cloning.NewCloner()
+	cloner.RegisterCloneFunc(reflect.TypeOf(&sync.Mutex{}), func(any interface{}) interface{} {
+		return &sync.Mutex{}
+	})
+	cloner.RegisterCloneFunc(reflect.TypeOf(&regexp.Regexp{}), func(any interface{}) interface{} {
+		return any
+	})
+	cloner.RegisterCloneFunc(reflect.TypeOf(&HookSession{}), func(any interface{}) interface{} {
+		session := any.(*HookSession)
+		return &HookSession{Name: session.Name, Hits: session.Hits}
+	})
+	clone := cloner.Clone(orig).(*HookModel)
+	if clone.Pool.Lock == orig.Pool.Lock || !clone.Pool.Lock.TryLock() {
+		log.Fail(t, "Expected a fresh unlocked mutex")
+		return
+	}
+	if clone.Pool.Pattern != orig.Pool.Pattern {
+		log.Fail(t, "Expected compiled regex to be shared")
+		return
+	}
+	if clone.Current.Hits != 5 || clone.Current.Token != "" {
+		log.Fail(t, "Expected registered func to override the DeepCloner interface")
+		return
+	}
+}
+
+func TestCloneRegisterCloneFuncAfterClone(t *testing.T) {
+	cloner := cloning.NewCloner()
+	first := cloner.Clone(newHookModel()).(*HookModel)
+	if first.Current.Name != "s1" {
+		log.Fail(t, "Expected session to be cloned by its DeepClone method")
+		return
+	}
+	cloner.RegisterCloneFunc(reflect.TypeOf(&HookSession{}), func(any interface{}) interface{} {
+		return &HookSession{Name: "registered"}
+	})
+	second := cloner.Clone(newHookModel()).(*HookModel)
+	if second.Current.Name != "registered" {
+		log.Fail(t, "Expected a clone func registered after cloning to be used")
+		return
+	}
+}
@@ -0,0 +1,224 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+	"github.com/saichler/l8reflect/go/reflect/helping"
+	"github.com/saichler/l8reflect/go/reflect/introspecting"
+)
+
+type MaskInfo struct {
+	Vendor string
+	Model  string
+}
+
+type MaskPort struct {
+	Name   string
+	Status string
+	Speed  int64
+}
+
+type MaskDevice struct {
+	Id     string
+	Info   *MaskInfo
+	Ports  []*MaskPort
+	Labels map[string]*MaskPort
+	Slots  [2]*MaskPort
+}
+
+func newMaskDevice() *MaskDevice {
+	return &MaskDevice{
+		Id:   "d1",
+		Info: &MaskInfo{Vendor: "v", Model: "m"},
+		Ports: []*MaskPort{
+			{Name: "eth0", Status: "up", Speed: 10},
+			{Name: "eth1", Status: "down", Speed: 20},
+		},
+		Labels: map[string]*MaskPort{
+			"a": {Name: "a", Status: "up", Speed: 1},
+			"b": {Name: "b", Status: "down", Speed: 2},
+		},
+		Slots: [2]*MaskPort{{Name: "s0", Status: "up"}, {Name: "s1", Status: "down"}},
+	}
+}
+
+func TestCloneMaskSubtrees(t *testing.T) {
+	device := newMaskDevice()
+	clone, err := cloning.NewCloner().CloneMask(device, "maskdevice.info", "maskdevice.ports<*>.status")
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	masked := clone.(*MaskDevice)
+	if masked.Id != "" || masked.Labels != nil || masked.Slots[0] != nil {
+		log.Fail(t, "Expected unmasked fields to be zero")
+		return
+	}
+	if masked.Info == nil || masked.Info.Vendor != "v" || masked.Info == device.Info {
+		log.Fail(t, "Expected info to be deep cloned")
+		return
+	}
+	if len(masked.Ports) != 2 || masked.Ports[1].Status != "down" || masked.Ports[1].Name != "" || masked.Ports[1].Speed != 0 {
+		log.Fail(t, "Expected only port status to be cloned")
+		return
+	}
+}
+
+func TestCloneMaskKeys(t *testing.T) {
+	device := newMaskDevice()
+	clone, err := cloning.NewCloner().CloneMask(device,
+		"maskdevice.labels<{24}b>.speed", "maskdevice.ports<1>", "maskdevice.slots<{2}0>.name")
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	masked := clone.(*MaskDevice)
+	if len(masked.Labels) != 1 || masked.Labels["b"] == nil || masked.Labels["b"].Speed != 2 || masked.Labels["b"].Name != "" {
+		log.Fail(t, "Expected only label b speed to be cloned")
+		return
+	}
+	if len(masked.Ports) != 2 || masked.Ports[0] != nil || masked.Ports[1].Name != "eth1" {
+		log.Fail(t, "Expected only port 1 to be cloned at its index")
+		return
+	}
+	if masked.Slots[0] == nil || masked.Slots[0].Name != "s0" || masked.Slots[0].Status != "" || masked.Slots[1] != nil {
+		log.Fail(t, "Expected only slot 0 name to be cloned")
+		return
+	}
+}
+
+func TestCloneMaskNoKeySelectsAll(t *testing.T) {
+	clone, err := cloning.NewCloner().CloneMask(newMaskDevice(), "maskdevice.labels.name")
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	masked := clone.(*MaskDevice)
+	if len(masked.Labels) != 2 || masked.Labels["a"].Name != "a" || masked.Labels["a"].Status != "" {
+		log.Fail(t, "Expected label names of all entries to be cloned")
+		return
+	}
+}
+
+func TestCloneMaskInvalidPath(t *testing.T) {
+	cloner := cloning.NewCloner()
+	_, err := cloner.CloneMask(newMaskDevice(), "maskdevice.nothere")
+	if err == nil {
+		log.Fail(t, "Expected error for unknown attribute")
+		return
+	}
+	_, err = cloner.CloneMask(newMaskDevice(), "otherdevice.info")
+	if err == nil {
+		log.Fail(t, "Expected error for wrong root type")
+		return
+	}
+	_, err = cloner.CloneMask(newMaskDevice(), "maskdevice.id<1>")
+	if err == nil {
+		log.Fail(t, "Expected error for key on non container attribute")
+		return
+	}
+}
+
+func TestPathSegments(t *testing.T) {
+	path := "device<{24}d1>.ports<{14}1.5>.status"
+	segments, err := helping.PathSegments(path)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if len(segments) != 3 || segments[0].Key != "{24}d1" || segments[1].Key != "{14}1.5" || segments[2].HasKey {
+		log.Fail(t, "Expected 3 segments with the keys kept whole, got ", segments)
+		return
+	}
+	if helping.JoinPathSegments(segments) != path {
+		log.Fail(t, "Expected the joined segments to be the path, got ", helping.JoinPathSegments(segments))
+		return
+	}
+	segments, err = helping.PathSegments("device.ports<>")
+	if err != nil || !segments[1].HasKey || segments[1].Key != "" {
+		log.Fail(t, "Expected an empty key")
+		return
+	}
+	for _, invalid := range []string{"device.ports<1", "device..ports", "device."} {
+		if _, err = helping.PathSegments(invalid); err == nil {
+			log.Fail(t, "Expected an error for ", invalid)
+			return
+		}
+	}
+}
+
+func TestCloneMaskMergesKeyedAndAllElements(t *testing.T) {
+	clone, err := cloning.NewCloner().CloneMask(newMaskDevice(),
+		"maskdevice.ports<{2}1>.status", "maskdevice.ports.name", "maskdevice.labels<*>.speed", "maskdevice.labels<{24}a>.status")
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	masked := clone.(*MaskDevice)
+	if masked.Ports[0].Name != "eth0" || masked.Ports[0].Status != "" {
+		log.Fail(t, "Expected only the name of port 0 to be cloned")
+		return
+	}
+	if masked.Ports[1].Name != "eth1" || masked.Ports[1].Status != "down" || masked.Ports[1].Speed != 0 {
+		log.Fail(t, "Expected the name and status of port 1 to be cloned")
+		return
+	}
+	if masked.Labels["a"].Speed != 1 || masked.Labels["a"].Status != "up" || masked.Labels["a"].Name != "" {
+		log.Fail(t, "Expected the speed and status of label a to be cloned")
+		return
+	}
+	if masked.Labels["b"].Speed != 2 || masked.Labels["b"].Status != "" {
+		log.Fail(t, "Expected only the speed of label b to be cloned")
+		return
+	}
+}
+
+func TestCloneMaskNodeTree(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{})
+	in := res.Introspector().(*introspecting.Introspector)
+	in.SetFieldPolicy(helping.NewNamePolicy("Speed"))
+	_, err := in.Inspect(&MaskDevice{})
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	cloner := cloning.NewCloner()
+	cloner.SetIntrospector(in)
+	_, err = cloner.CloneMask(newMaskDevice(), "maskdevice.ports.speed")
+	if err == nil {
+		log.Fail(t, "Expected an error for a path the node tree skips")
+		return
+	}
+	clone, err := cloner.CloneMask(newMaskDevice(), "maskdevice.ports.name", "maskdevice.info.vendor")
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	masked := clone.(*MaskDevice)
+	if masked.Ports[1].Name != "eth1" || masked.Info.Vendor != "v" {
+		log.Fail(t, "Expected paths in the node tree to be cloned")
+		return
+	}
+
+	cloner = cloning.NewCloner()
+	cloner.SetFieldPolicy(helping.NewNamePolicy("Speed"))
+	_, err = cloner.CloneMask(newMaskDevice(), "maskdevice.ports.speed")
+	if err == nil {
+		log.Fail(t, "Expected an error for a path the Cloner skips")
+		return
+	}
+}
@@ -0,0 +1,112 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+	"github.com/saichler/l8reflect/go/reflect/introspecting"
+)
+
+type RefSite struct {
+	SiteId string
+	Name   string
+	Racks  []*RefRack
+}
+
+type RefRack struct {
+	RackId string
+	Site   *RefSite
+	Peers  map[string]*RefRack
+	Owner  *RefSite
+}
+
+func newRefSite() *RefSite {
+	site := &RefSite{SiteId: "s1", Name: "site"}
+	rack1 := &RefRack{RackId: "r1", Site: site, Owner: site, Peers: map[string]*RefRack{}}
+	rack2 := &RefRack{RackId: "r2", Site: site, Owner: site, Peers: map[string]*RefRack{}}
+	rack1.Peers["r2"] = rack2
+	rack2.Peers["r1"] = rack1
+	site.Racks = []*RefRack{rack1, rack2}
+	return site
+}
+
+func newRefIntrospector(t *testing.T) *introspecting.Introspector {
+	res := newLocalResources(map[interface{}]string{&RefSite{}: "SiteId", &RefRack{}: "RackId"})
+	in := res.Introspector().(*introspecting.Introspector)
+	err := in.AddReferenceDecorator(&RefRack{}, "Owner")
+	if err != nil {
+		log.Fail(t, err.Error())
+		return nil
+	}
+	err = in.AddReferenceKeyDecorator(&RefRack{}, "Peers", "Site")
+	if err != nil {
+		log.Fail(t, err.Error())
+		return nil
+	}
+	return in
+}
+
+func TestCloneReferenceDecorators(t *testing.T) {
+	in := newRefIntrospector(t)
+	if in == nil {
+		return
+	}
+	site := newRefSite()
+	clone := in.Clone(site).(*RefSite)
+	rack := clone.Racks[0]
+	if rack == site.Racks[0] || rack.RackId != "r1" {
+		log.Fail(t, "Expected owned racks to be deep cloned")
+		return
+	}
+	if rack.Owner != site {
+		log.Fail(t, "Expected reference field to be copied as is")
+		return
+	}
+	if rack.Site == site || rack.Site.SiteId != "s1" || rack.Site.Name != "" || rack.Site.Racks != nil {
+		log.Fail(t, "Expected reference key field to hold only the site key")
+		return
+	}
+	peer := rack.Peers["r2"]
+	if peer == nil || peer == site.Racks[1] || peer.RackId != "r2" || peer.Peers != nil || peer.Site != nil {
+		log.Fail(t, "Expected reference key map to hold only the rack keys")
+		return
+	}
+}
+
+func TestCloneReferencePolicyOnCloner(t *testing.T) {
+	in := newRefIntrospector(t)
+	if in == nil {
+		return
+	}
+	site := newRefSite()
+	plain := cloning.NewCloner().Clone(site).(*RefSite)
+	if plain.Racks[0].Owner == site || plain.Racks[0].Site.Name != "site" {
+		log.Fail(t, "Expected a cloner without reference policy to deep clone")
+		return
+	}
+	cloner := cloning.NewCloner()
+	cloner.SetReferencePolicy(in.ReferenceDecoratorPolicy())
+	clone, err := cloner.CloneMask(site, "refsite.racks<{2}0>")
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	rack := clone.(*RefSite).Racks[0]
+	if rack.Owner != site || rack.Site.Name != "" {
+		log.Fail(t, "Expected CloneMask to honour the reference policy")
+		return
+	}
+}
@@ -0,0 +1,160 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+)
+
+type CopyPort struct {
+	Name   string
+	Status int32
+}
+
+type CopyDevice struct {
+	Id     string
+	Info   *CopyPort
+	Ports  []*CopyPort
+	ByName map[string]*CopyPort
+	Tags   []string
+	Any    interface{}
+	Parent *CopyDevice
+	cache  string
+}
+
+func newCopySource() *CopyDevice {
+	port := &CopyPort{Name: "eth0", Status: 1}
+	src := &CopyDevice{
+		Id:     "src",
+		Info:   &CopyPort{Name: "info", Status: 2},
+		Ports:  []*CopyPort{port},
+		ByName: map[string]*CopyPort{"eth0": port},
+		Tags:   []string{"a"},
+		Any:    &CopyPort{Name: "any"},
+	}
+	src.Parent = src
+	return src
+}
+
+func TestCopyIntoReusesDestination(t *testing.T) {
+	src := newCopySource()
+	info := &CopyPort{Name: "old"}
+	port := &CopyPort{Name: "old0"}
+	stale := &CopyPort{Name: "stale"}
+	any := &CopyPort{Name: "oldany"}
+	ports := make([]*CopyPort, 2, 4)
+	ports[0] = port
+	dst := &CopyDevice{Id: "dst", Info: info, Ports: ports, Tags: []string{"x", "y"}, Any: any,
+		ByName: map[string]*CopyPort{"eth0": port, "eth9": stale}, cache: "keep"}
+
+	err := cloning.NewCloner().CopyInto(dst, src)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if dst.Id != "src" || dst.Info != info || info.Name != "info" || info.Status != 2 {
+		log.Fail(t, "Expected existing sub struct to be refreshed in place")
+		return
+	}
+	if len(dst.Ports) != 1 || &dst.Ports[0] != &ports[0] || dst.Ports[0] != port || port.Name != "eth0" {
+		log.Fail(t, "Expected slice and its elements to be reused")
+		return
+	}
+	if len(dst.ByName) != 1 || dst.ByName["eth0"] != port {
+		log.Fail(t, "Expected map entries to be reused and missing keys deleted")
+		return
+	}
+	if dst.Any != any || any.Name != "any" {
+		log.Fail(t, "Expected interface value of the same type to be refreshed in place")
+		return
+	}
+	if dst.Parent != dst || dst.cache != "keep" {
+		log.Fail(t, "Expected back reference to map to the destination and skipped fields to be kept")
+		return
+	}
+	src.Info.Name = "changed"
+	src.Tags[0] = "changed"
+	if info.Name != "info" || dst.Tags[0] != "a" {
+		log.Fail(t, "Expected destination not to share data with the source")
+		return
+	}
+}
+
+func TestCopyIntoSharedDestination(t *testing.T) {
+	shared := &CopyPort{Name: "shared"}
+	sharedPorts := make([]*CopyPort, 3, 4)
+	sharedPorts[1] = &CopyPort{Name: "stale"}
+	sharedMap := map[string]*CopyPort{}
+	dst := &CopyDevice{Info: shared, Parent: &CopyDevice{Info: shared, Ports: sharedPorts, ByName: sharedMap},
+		Ports: sharedPorts, ByName: sharedMap}
+	src := &CopyDevice{Info: &CopyPort{Name: "a"}, Ports: []*CopyPort{{Name: "a0"}},
+		ByName: map[string]*CopyPort{"a": {Name: "a"}},
+		Parent: &CopyDevice{Info: &CopyPort{Name: "b"}, Ports: []*CopyPort{{Name: "b0"}, {Name: "b1"}},
+			ByName: map[string]*CopyPort{"b": {Name: "b"}}}}
+	err := cloning.NewCloner().CopyInto(dst, src)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if dst.Info != shared || dst.Info.Name != "a" || dst.Parent.Info == shared || dst.Parent.Info.Name != "b" {
+		log.Fail(t, "Expected a pointed-to struct shared by two destinations to be reused once")
+		return
+	}
+	if dst.Ports[0].Name != "a0" || dst.Parent.Ports[0].Name != "b0" || dst.Parent.Ports[1].Name != "b1" ||
+		&dst.Ports[0] != &sharedPorts[0] || &dst.Parent.Ports[0] == &sharedPorts[0] {
+		log.Fail(t, "Expected a backing array shared by two destinations to be reused once")
+		return
+	}
+	if sharedPorts[1] != nil || sharedPorts[2] != nil {
+		log.Fail(t, "Expected the elements past the length of a shrunk slice to be cleared")
+		return
+	}
+	if len(dst.ByName) != 1 || dst.ByName["a"].Name != "a" || len(dst.Parent.ByName) != 1 || dst.Parent.ByName["b"].Name != "b" {
+		log.Fail(t, "Expected a map shared by two destinations to be reused once")
+		return
+	}
+}
+
+func TestCopyIntoNilsAndValues(t *testing.T) {
+	dst := newCopySource()
+	src := CopyDevice{Id: "value"}
+	err := cloning.NewCloner().CopyInto(dst, src)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if dst.Id != "value" || dst.Info != nil || dst.Ports != nil || dst.ByName != nil || dst.Any != nil || dst.Parent != nil {
+		log.Fail(t, "Expected nil source fields to clear the destination")
+		return
+	}
+}
+
+func TestCopyIntoErrors(t *testing.T) {
+	cloner := cloning.NewCloner()
+	if cloner.CopyInto(CopyDevice{}, &CopyDevice{}) == nil {
+		log.Fail(t, "Expected error for a non pointer destination")
+		return
+	}
+	var nilDevice *CopyDevice
+	if cloner.CopyInto(nilDevice, &CopyDevice{}) == nil {
+		log.Fail(t, "Expected error for a nil destination")
+		return
+	}
+	if cloner.CopyInto(&CopyDevice{}, &CopyPort{}) == nil {
+		log.Fail(t, "Expected error for a type mismatch")
+		return
+	}
+}
@@ -0,0 +1,117 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+)
+
+type KindsSample struct {
+	Extra     interface{}
+	Checksum  [4]byte
+	Impedance complex128
+	Handle    uintptr
+	Events    chan int
+	Callback  func() string
+	Raw       unsafe.Pointer
+}
+
+func kindsCallback() string {
+	return "callback"
+}
+
+func newKindsSample(events chan int, raw unsafe.Pointer) *KindsSample {
+	return &KindsSample{
+		Extra:     map[string]interface{}{"vlan": 10, "tags": []string{"a", "b"}},
+		Checksum:  [4]byte{1, 2, 3, 4},
+		Impedance: complex(50, -1.5),
+		Handle:    7,
+		Events:    events,
+		Callback:  kindsCallback,
+		Raw:       raw,
+	}
+}
+
+func TestDeepEqualAllKinds(t *testing.T) {
+	events := make(chan int)
+	value := 5
+	raw := unsafe.Pointer(&value)
+	deepEqual := cloning.NewDeepEqual()
+	if !deepEqual.Equal(newKindsSample(events, raw), newKindsSample(events, raw)) {
+		log.Fail(t, "Expected samples with the same values and identities to be equal")
+		return
+	}
+	changes := []func(*KindsSample){
+		func(s *KindsSample) { s.Extra = map[string]interface{}{"vlan": 11, "tags": []string{"a", "b"}} },
+		func(s *KindsSample) { s.Extra = "vlan" },
+		func(s *KindsSample) { s.Extra = nil },
+		func(s *KindsSample) { s.Checksum[3] = 5 },
+		func(s *KindsSample) { s.Impedance = complex(50, 1.5) },
+		func(s *KindsSample) { s.Handle = 8 },
+		func(s *KindsSample) { s.Events = make(chan int) },
+		func(s *KindsSample) { s.Callback = nil },
+		func(s *KindsSample) { s.Callback = func() string { return "other" } },
+		func(s *KindsSample) { s.Raw = nil },
+	}
+	for i, change := range changes {
+		changed := newKindsSample(events, raw)
+		change(changed)
+		if deepEqual.Equal(newKindsSample(events, raw), changed) {
+			log.Fail(t, "Expected change ", i, " to make the samples differ")
+			return
+		}
+	}
+}
+
+func TestDeepEqualInterfaceDynamicType(t *testing.T) {
+	deepEqual := cloning.NewDeepEqual()
+	if deepEqual.Equal(&KindsSample{Extra: int32(1)}, &KindsSample{Extra: int64(1)}) {
+		log.Fail(t, "Expected interfaces holding different types to differ")
+		return
+	}
+	if !deepEqual.Equal(&KindsSample{Extra: &KindsSample{Handle: 1}}, &KindsSample{Extra: &KindsSample{Handle: 1}}) {
+		log.Fail(t, "Expected interfaces holding equal pointers to be equal")
+		return
+	}
+	if !deepEqual.Equal(&KindsSample{}, &KindsSample{}) {
+		log.Fail(t, "Expected zero samples to be equal")
+		return
+	}
+}
+
+type panickingEqualer struct {
+	Name string
+}
+
+func (this *panickingEqualer) DeepEqual(other interface{}) bool {
+	panic("cannot compare " + this.Name)
+}
+
+func TestDeepEqualWithError(t *testing.T) {
+	deepEqual := cloning.NewDeepEqual()
+	eq, err := deepEqual.EqualWithError(newKindsSample(nil, nil), newKindsSample(nil, nil))
+	if err != nil || !eq {
+		log.Fail(t, "Expected equal samples without error, got ", err)
+		return
+	}
+	eq, err = deepEqual.EqualWithError(&panickingEqualer{Name: "a"}, &panickingEqualer{Name: "a"})
+	if err == nil || eq || !strings.Contains(err.Error(), "cannot compare a") {
+		log.Fail(t, "Expected the panic to be returned as an error")
+		return
+	}
+}
@@ -0,0 +1,79 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+)
+
+type CycleNode struct {
+	Name     string
+	Parent   *CycleNode
+	Next     *CycleNode
+	Prev     *CycleNode
+	Children []*CycleNode
+	Index    map[string]*CycleNode
+}
+
+// newCycleTree builds a root with children pointing back to it,
+// linked to each other in a doubly linked ring.
+func newCycleTree(names ...string) *CycleNode {
+	root := &CycleNode{Name: "root", Index: make(map[string]*CycleNode)}
+	for _, name := range names {
+		child := &CycleNode{Name: name, Parent: root}
+		root.Children = append(root.Children, child)
+		root.Index[name] = child
+	}
+	for i, child := range root.Children {
+		child.Next = root.Children[(i+1)%len(root.Children)]
+		child.Prev = root.Children[(i+len(root.Children)-1)%len(root.Children)]
+	}
+	root.Next = root
+	return root
+}
+
+func TestDeepEqualCycles(t *testing.T) {
+	deepEqual := cloning.NewDeepEqual()
+	if !deepEqual.Equal(newCycleTree("a", "b", "c"), newCycleTree("a", "b", "c")) {
+		log.Fail(t, "Expected equal cyclic graphs to be equal")
+		return
+	}
+	zSide := newCycleTree("a", "b", "c")
+	zSide.Children[2].Name = "d"
+	if deepEqual.Equal(newCycleTree("a", "b", "c"), zSide) {
+		log.Fail(t, "Expected cyclic graphs with different names to differ")
+		return
+	}
+	differences := deepEqual.EqualReport(newCycleTree("a", "b", "c"), zSide, 0)
+	if len(differences) != 1 || differences[0].PropertyId != "cyclenode.children<{2}0>.next.next.name" {
+		log.Fail(t, "Expected the renamed node to be reported once at its first path")
+		return
+	}
+}
+
+func TestDeepEqualCloneOfCycle(t *testing.T) {
+	orig := newCycleTree("a", "b")
+	clone := cloning.NewCloner().Clone(orig).(*CycleNode)
+	if !cloning.NewDeepEqual().Equal(orig, clone) {
+		log.Fail(t, "Expected the clone of a cyclic graph to be equal to it")
+		return
+	}
+	clone.Children[1].Prev.Name = "x"
+	if cloning.NewDeepEqual().Equal(orig, clone) {
+		log.Fail(t, "Expected the changed clone to differ")
+		return
+	}
+}
@@ -0,0 +1,238 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+	"github.com/saichler/l8reflect/go/reflect/updating"
+)
+
+type TelemetryCounter struct {
+	Name    string
+	Packets int64
+}
+
+type TelemetrySample struct {
+	Id       string
+	Cpu      float64
+	LastSeen int64
+	Counters []*TelemetryCounter
+	Tags     []string
+	Labels   map[string]string
+}
+
+func newTelemetrySample() *TelemetrySample {
+	return &TelemetrySample{
+		Id:       "t1",
+		Cpu:      42.5,
+		LastSeen: 100,
+		Counters: []*TelemetryCounter{{Name: "in", Packets: 10}, {Name: "out", Packets: 20}},
+	}
+}
+
+func telemetryOptions() *cloning.EqualOptions {
+	return &cloning.EqualOptions{
+		FloatAbsTolerance: 0.01,
+		NaNEqual:          true,
+		NilAsEmpty:        true,
+		IgnorePaths:       []string{"telemetrysample.lastseen", "telemetrysample.counters.packets"},
+	}
+}
+
+func TestEqualOptionsDefault(t *testing.T) {
+	aSide := newTelemetrySample()
+	zSide := newTelemetrySample()
+	zSide.Tags = []string{}
+	if cloning.NewDeepEqual().Equal(aSide, zSide) {
+		log.Fail(t, "Expected nil and empty slices to differ by default")
+		return
+	}
+	if cloning.NewDeepEqual().FloatEqual(math.NaN(), math.NaN()) {
+		log.Fail(t, "Expected NaN to differ from NaN by default")
+		return
+	}
+}
+
+func TestEqualOptions(t *testing.T) {
+	deepEqual := cloning.NewDeepEqual()
+	err := deepEqual.SetOptions(telemetryOptions())
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	aSide := newTelemetrySample()
+	zSide := newTelemetrySample()
+	zSide.Cpu = 42.505
+	zSide.LastSeen = 200
+	zSide.Counters[1].Packets = 25
+	zSide.Tags = []string{}
+	zSide.Labels = map[string]string{}
+	if !deepEqual.Equal(aSide, zSide) {
+		log.Fail(t, "Expected samples to be equal with the options, got ",
+			deepEqual.EqualReport(aSide, zSide, 1)[0].PropertyId)
+		return
+	}
+	aSide.Cpu = math.NaN()
+	zSide.Cpu = math.NaN()
+	if !deepEqual.Equal(aSide, zSide) {
+		log.Fail(t, "Expected NaN to equal NaN")
+		return
+	}
+	zSide.Cpu = 43
+	zSide.Counters[1].Name = "drop"
+	differences := deepEqual.EqualReport(aSide, zSide, 0)
+	if len(differences) != 2 || differences[0].PropertyId != "telemetrysample.cpu" ||
+		differences[1].PropertyId != "telemetrysample.counters<{2}1>.name" {
+		log.Fail(t, "Expected only the cpu and counter name to differ")
+		return
+	}
+}
+
+func TestEqualOptionsRelativeTolerance(t *testing.T) {
+	deepEqual := cloning.NewDeepEqual()
+	deepEqual.SetOptions(&cloning.EqualOptions{FloatRelTolerance: 0.001})
+	if !deepEqual.FloatEqual(1000, 1000.5) || deepEqual.FloatEqual(1, 1.5) {
+		log.Fail(t, "Expected the tolerance to be relative to the magnitude")
+		return
+	}
+}
+
+func TestEqualOptionsIgnoredPath(t *testing.T) {
+	deepEqual := cloning.NewDeepEqual()
+	err := deepEqual.SetOptions(&cloning.EqualOptions{IgnorePaths: []string{"telemetrysample.counters<{2}1>"}})
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if !deepEqual.IgnoredPath("telemetrysample.counters<{2}1>.packets") ||
+		deepEqual.IgnoredPath("telemetrysample.counters<{2}0>.packets") {
+		log.Fail(t, "Expected only the second counter to be ignored")
+		return
+	}
+	err = deepEqual.SetOptions(&cloning.EqualOptions{IgnorePaths: []string{"telemetrysample.counters<1"}})
+	if err == nil {
+		log.Fail(t, "Expected an error for a malformed path")
+		return
+	}
+}
+
+func TestEqualOptionsIgnoredMapKey(t *testing.T) {
+	deepEqual := cloning.NewDeepEqual()
+	err := deepEqual.SetOptions(&cloning.EqualOptions{IgnorePaths: []string{"telemetrysample.labels<{24}temp>"}})
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	aSide := newTelemetrySample()
+	aSide.Labels = map[string]string{"site": "dc1", "temp": "40"}
+	zSide := newTelemetrySample()
+	zSide.Labels = map[string]string{"site": "dc1"}
+	if !deepEqual.Equal(aSide, zSide) || len(deepEqual.EqualReport(aSide, zSide, 0)) != 0 {
+		log.Fail(t, "Expected maps differing only in an ignored key to be equal")
+		return
+	}
+	zSide.Labels = map[string]string{"site": "dc1", "rack": "4"}
+	if deepEqual.Equal(aSide, zSide) || len(deepEqual.EqualReport(aSide, zSide, 0)) != 1 {
+		log.Fail(t, "Expected a key that is not ignored to differ")
+		return
+	}
+}
+
+type SharedPort struct {
+	Name string
+	Seen int64
+}
+
+type SharedDevice struct {
+	Primary *SharedPort
+	Ports   []*SharedPort
+}
+
+func newSharedDevice(seen int64) *SharedDevice {
+	port := &SharedPort{Name: "eth0", Seen: seen}
+	return &SharedDevice{Primary: port, Ports: []*SharedPort{port}}
+}
+
+func TestEqualOptionsIgnoredSharedPointer(t *testing.T) {
+	deepEqual := cloning.NewDeepEqual()
+	err := deepEqual.SetOptions(&cloning.EqualOptions{IgnorePaths: []string{"shareddevice.primary.seen"}})
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if deepEqual.Equal(newSharedDevice(1), newSharedDevice(2)) {
+		log.Fail(t, "Expected a shared pointer to be compared under the paths that are not ignored")
+		return
+	}
+	differences := deepEqual.EqualReport(newSharedDevice(1), newSharedDevice(2), 0)
+	if len(differences) != 1 || differences[0].PropertyId != "shareddevice.ports<{2}0>.seen" {
+		log.Fail(t, "Expected a single difference under the ports")
+		return
+	}
+	err = deepEqual.SetOptions(&cloning.EqualOptions{IgnorePaths: []string{"shareddevice.primary.seen", "shareddevice.ports"}})
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if !deepEqual.Equal(newSharedDevice(1), newSharedDevice(2)) {
+		log.Fail(t, "Expected the differences under all ignored paths to be ignored")
+		return
+	}
+}
+
+func TestEqualOptionsUpdater(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{&TelemetrySample{}: "Id"})
+	aSide := newTelemetrySample()
+	zSide := newTelemetrySample()
+	zSide.Cpu = 42.505
+	zSide.LastSeen = 200
+	zSide.Counters[0].Packets = 11
+	zSide.Tags = []string{}
+
+	upd := updating.NewUpdater(res, true, false)
+	err := upd.SetEqualOptions(telemetryOptions())
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	err = upd.Update(aSide, zSide)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if len(upd.Changes()) != 0 {
+		log.Fail(t, "Expected no changes but got ", len(upd.Changes()))
+		return
+	}
+	if aSide.LastSeen != 100 || aSide.Counters[0].Packets != 10 || aSide.Cpu != 42.5 {
+		log.Fail(t, "Expected ignored and tolerated values not to be updated")
+		return
+	}
+
+	zSide.Cpu = 50
+	upd = updating.NewUpdater(res, true, false)
+	upd.SetEqualOptions(telemetryOptions())
+	err = upd.Update(aSide, zSide)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if len(upd.Changes()) != 1 || aSide.Cpu != 50 {
+		log.Fail(t, "Expected only the cpu to change")
+		return
+	}
+}
@@ -0,0 +1,116 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+)
+
+type ReportPort struct {
+	Name   string
+	Status int32
+}
+
+type ReportDevice struct {
+	Id     string
+	Info   *ReportPort
+	Ports  []*ReportPort
+	Labels map[string]string
+}
+
+func newReportDevice() *ReportDevice {
+	return &ReportDevice{
+		Id:     "d1",
+		Info:   &ReportPort{Name: "info"},
+		Ports:  []*ReportPort{{Name: "p0", Status: 1}, {Name: "p1", Status: 1}},
+		Labels: map[string]string{"site": "a", "rack": "1"},
+	}
+}
+
+func reportIds(differences []*cloning.Difference) map[string]*cloning.Difference {
+	ids := make(map[string]*cloning.Difference)
+	for _, difference := range differences {
+		ids[difference.PropertyId] = difference
+	}
+	return ids
+}
+
+func TestEqualReportNoDifferences(t *testing.T) {
+	differences := cloning.NewDeepEqual().EqualReport(newReportDevice(), newReportDevice(), 0)
+	if len(differences) != 0 {
+		log.Fail(t, "Expected no differences, got ", differences[0].PropertyId)
+		return
+	}
+}
+
+func TestEqualReportAllDifferences(t *testing.T) {
+	aSide := newReportDevice()
+	zSide := newReportDevice()
+	zSide.Info.Name = "other"
+	zSide.Ports[1].Status = 2
+	zSide.Ports = append(zSide.Ports, &ReportPort{Name: "p2"})
+	zSide.Labels["site"] = "b"
+	delete(zSide.Labels, "rack")
+	zSide.Labels["row"] = "3"
+
+	differences := cloning.NewDeepEqual().EqualReport(aSide, zSide, 0)
+	ids := reportIds(differences)
+	expected := map[string][2]interface{}{
+		"reportdevice.info.name":          {"info", "other"},
+		"reportdevice.ports<{2}1>.status": {int32(1), int32(2)},
+		"reportdevice.labels<{24}site>":   {"a", "b"},
+		"reportdevice.labels<{24}rack>":   {"1", nil},
+		"reportdevice.labels<{24}row>":    {nil, "3"},
+	}
+	if len(differences) != len(expected)+1 {
+		log.Fail(t, "Expected ", len(expected)+1, " differences, got ", len(differences))
+		return
+	}
+	for id, values := range expected {
+		difference, ok := ids[id]
+		if !ok {
+			log.Fail(t, "Expected a difference at ", id)
+			return
+		}
+		if difference.ASide != values[0] || difference.ZSide != values[1] {
+			log.Fail(t, "Unexpected values at ", id, ": ", difference.ASide, " ", difference.ZSide)
+			return
+		}
+	}
+	added, ok := ids["reportdevice.ports<{2}2>"]
+	if !ok || added.ASide != nil || added.ZSide.(*ReportPort).Name != "p2" {
+		log.Fail(t, "Expected the added port to be reported")
+		return
+	}
+}
+
+func TestEqualReportLimit(t *testing.T) {
+	aSide := newReportDevice()
+	zSide := newReportDevice()
+	zSide.Id = "d2"
+	zSide.Info = nil
+	zSide.Ports[0].Name = "other"
+	differences := cloning.NewDeepEqual().EqualReport(aSide, zSide, 1)
+	if len(differences) != 1 || differences[0].PropertyId != "reportdevice.id" {
+		log.Fail(t, "Expected only the first difference")
+		return
+	}
+	differences = cloning.NewDeepEqual().EqualReport(aSide, zSide, 0)
+	if len(differences) != 3 || differences[1].PropertyId != "reportdevice.info" || differences[1].ZSide.(*ReportPort) != nil {
+		log.Fail(t, "Expected the nil pointer to be reported at its field")
+		return
+	}
+}
@@ -0,0 +1,160 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+	"github.com/saichler/l8reflect/go/reflect/helping"
+	"github.com/saichler/l8reflect/go/reflect/introspecting"
+	"github.com/saichler/l8reflect/go/reflect/updating"
+)
+
+type PolicyModel struct {
+	Id        string
+	Name      string
+	CacheHits int32
+	Transient string `l8:"transient"`
+	Lock      *sync.Mutex
+	Secret    string
+}
+
+func policies() []helping.FieldPolicy {
+	return []helping.FieldPolicy{
+		helping.NewNamePolicy("Cache*"),
+		helping.NewTagPolicy("l8", "transient"),
+		helping.NewTypePolicy(reflect.TypeOf(&sync.Mutex{})),
+	}
+}
+
+func newPolicyModel() *PolicyModel {
+	return &PolicyModel{Id: "p1", Name: "name", CacheHits: 7, Transient: "tmp", Lock: &sync.Mutex{}, Secret: "s"}
+}
+
+func TestFieldPolicyCloner(t *testing.T) {
+	cloner := cloning.NewCloner()
+	cloner.SetFieldPolicy(policies()...)
+	clone := cloner.Clone(newPolicyModel()).(*PolicyModel)
+	if clone.Name != "name" || clone.Secret != "s" {
+		log.Fail(t, "Expected regular fields to be cloned")
+		return
+	}
+	if clone.CacheHits != 0 || clone.Transient != "" || clone.Lock != nil {
+		log.Fail(t, "Expected policy fields to be skipped")
+		return
+	}
+}
+
+func TestFieldPolicyDeepEqual(t *testing.T) {
+	aside := newPolicyModel()
+	zside := newPolicyModel()
+	zside.CacheHits = 8
+	zside.Transient = "other"
+	if cloning.NewDeepEqual().Equal(aside, zside) {
+		log.Fail(t, "Expected default policy to detect the difference")
+		return
+	}
+	de := cloning.NewDeepEqual()
+	de.SetFieldPolicy(policies()...)
+	if !de.Equal(aside, zside) {
+		log.Fail(t, "Expected policy fields to be ignored")
+		return
+	}
+}
+
+func TestFieldPolicyIntrospector(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{})
+	in := res.Introspector().(*introspecting.Introspector)
+	in.SetFieldPolicy(policies()...)
+	_, err := in.Inspect(&PolicyModel{})
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if _, ok := in.Node("policymodel.cachehits"); ok {
+		log.Fail(t, "Expected name policy field not to be inspected")
+		return
+	}
+	if _, ok := in.Node("policymodel.transient"); ok {
+		log.Fail(t, "Expected tag policy field not to be inspected")
+		return
+	}
+	if _, ok := in.Node("policymodel.name"); !ok {
+		log.Fail(t, "Expected regular field to be inspected")
+		return
+	}
+}
+
+func TestFieldPolicyUpdater(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{&PolicyModel{}: "Id"})
+	aside := newPolicyModel()
+	zside := newPolicyModel()
+	zside.CacheHits = 9
+	zside.Transient = "other"
+
+	upd := updating.NewUpdater(res, false, false)
+	upd.SetFieldPolicy(policies()...)
+	err := upd.Update(aside, zside)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if len(upd.Changes()) != 0 {
+		log.Fail(t, "Expected no changes but got ", len(upd.Changes()))
+		return
+	}
+	if aside.CacheHits != 7 {
+		log.Fail(t, "Expected policy field not to be updated")
+		return
+	}
+}
+
+func TestFieldPolicySkipDecorator(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{&PolicyModel{}: "Id"})
+	in := res.Introspector().(*introspecting.Introspector)
+	err := in.AddSkipDecorator(&PolicyModel{}, "Secret")
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if _, ok := in.Node("policymodel.secret"); ok {
+		log.Fail(t, "Expected decorated field to be removed from the node tree")
+		return
+	}
+
+	cloner := cloning.NewCloner()
+	cloner.SetFieldPolicy(in.SkipDecoratorPolicy())
+	clone := cloner.Clone(newPolicyModel()).(*PolicyModel)
+	if clone.Secret != "" || clone.Name != "name" {
+		log.Fail(t, "Expected only the decorated field to be skipped")
+		return
+	}
+
+	aside := newPolicyModel()
+	zside := newPolicyModel()
+	zside.Secret = "changed"
+	upd := updating.NewUpdater(res, false, false)
+	err = upd.Update(aside, zside)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if len(upd.Changes()) != 0 || aside.Secret != "s" {
+		log.Fail(t, "Expected decorated field to be ignored by the updater")
+		return
+	}
+}
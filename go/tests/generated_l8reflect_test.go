@@ -0,0 +1,255 @@
+// Code generated by l8reflect-gen. DO NOT EDIT.
+
+package tests
+
+import (
+	"bytes"
+)
+
+// DeepClone returns a deep copy of the GenDevice, see cloning.DeepCloner.
+func (this *GenDevice) DeepClone() interface{} {
+	return l8reflectCloneGenDevice(this)
+}
+
+// DeepEqual returns true if other is a *GenDevice deeply equal to this one, see cloning.DeepEqualer.
+func (this *GenDevice) DeepEqual(other interface{}) bool {
+	that, ok := other.(*GenDevice)
+	if !ok {
+		return false
+	}
+	return l8reflectEqualGenDevice(this, that)
+}
+
+func l8reflectCloneGenDevice(src *GenDevice) *GenDevice {
+	if src == nil {
+		return nil
+	}
+	dst := &GenDevice{}
+	dst.Id = src.Id
+	dst.Status = src.Status
+	dst.Info = l8reflectCloneGenInfo(src.Info)
+	dst.Location = *l8reflectCloneGenInfo(&src.Location)
+	if src.Ports != nil {
+		dst.Ports = make([]*GenPort, len(src.Ports))
+		for i0 := range src.Ports {
+			dst.Ports[i0] = l8reflectCloneGenPort(src.Ports[i0])
+		}
+	}
+	if src.PortsByName != nil {
+		dst.PortsByName = make(map[string]*GenPort, len(src.PortsByName))
+		for k0, v0 := range src.PortsByName {
+			var c0 *GenPort
+			c0 = l8reflectCloneGenPort(v0)
+			dst.PortsByName[k0] = c0
+		}
+	}
+	if src.Groups != nil {
+		dst.Groups = make(map[string][]*GenPort, len(src.Groups))
+		for k0, v0 := range src.Groups {
+			var c0 []*GenPort
+			if v0 != nil {
+				c0 = make([]*GenPort, len(v0))
+				for i1 := range v0 {
+					c0[i1] = l8reflectCloneGenPort(v0[i1])
+				}
+			}
+			dst.Groups[k0] = c0
+		}
+	}
+	if src.Tags != nil {
+		dst.Tags = make([]string, len(src.Tags))
+		copy(dst.Tags, src.Tags)
+	}
+	if src.Labels != nil {
+		dst.Labels = make(map[string]string, len(src.Labels))
+		for k0, v0 := range src.Labels {
+			dst.Labels[k0] = v0
+		}
+	}
+	for i0 := range src.Slots {
+		dst.Slots[i0] = l8reflectCloneGenPort(src.Slots[i0])
+	}
+	dst.Weights = src.Weights
+	if src.Matrix != nil {
+		dst.Matrix = make([][]int32, len(src.Matrix))
+		for i0 := range src.Matrix {
+			if src.Matrix[i0] != nil {
+				dst.Matrix[i0] = make([]int32, len(src.Matrix[i0]))
+				copy(dst.Matrix[i0], src.Matrix[i0])
+			}
+		}
+	}
+	if src.Optional != nil {
+		var p0 string
+		p0 = (*src.Optional)
+		dst.Optional = &p0
+	}
+	return dst
+}
+
+func l8reflectEqualGenDevice(a, b *GenDevice) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Id != b.Id {
+		return false
+	}
+	if a.Status != b.Status {
+		return false
+	}
+	if !l8reflectEqualGenInfo(a.Info, b.Info) {
+		return false
+	}
+	if !l8reflectEqualGenInfo(&a.Location, &b.Location) {
+		return false
+	}
+	if (a.Ports == nil) != (b.Ports == nil) || len(a.Ports) != len(b.Ports) {
+		return false
+	}
+	for i0 := range a.Ports {
+		if !l8reflectEqualGenPort(a.Ports[i0], b.Ports[i0]) {
+			return false
+		}
+	}
+	if (a.PortsByName == nil) != (b.PortsByName == nil) || len(a.PortsByName) != len(b.PortsByName) {
+		return false
+	}
+	for k0, av0 := range a.PortsByName {
+		bv0, ok := b.PortsByName[k0]
+		if !ok {
+			return false
+		}
+		if !l8reflectEqualGenPort(av0, bv0) {
+			return false
+		}
+	}
+	if (a.Groups == nil) != (b.Groups == nil) || len(a.Groups) != len(b.Groups) {
+		return false
+	}
+	for k0, av0 := range a.Groups {
+		bv0, ok := b.Groups[k0]
+		if !ok {
+			return false
+		}
+		if (av0 == nil) != (bv0 == nil) || len(av0) != len(bv0) {
+			return false
+		}
+		for i1 := range av0 {
+			if !l8reflectEqualGenPort(av0[i1], bv0[i1]) {
+				return false
+			}
+		}
+	}
+	if (a.Tags == nil) != (b.Tags == nil) || len(a.Tags) != len(b.Tags) {
+		return false
+	}
+	for i0 := range a.Tags {
+		if a.Tags[i0] != b.Tags[i0] {
+			return false
+		}
+	}
+	if (a.Labels == nil) != (b.Labels == nil) || len(a.Labels) != len(b.Labels) {
+		return false
+	}
+	for k0, av0 := range a.Labels {
+		bv0, ok := b.Labels[k0]
+		if !ok {
+			return false
+		}
+		if av0 != bv0 {
+			return false
+		}
+	}
+	for i0 := range a.Slots {
+		if !l8reflectEqualGenPort(a.Slots[i0], b.Slots[i0]) {
+			return false
+		}
+	}
+	if a.Weights != b.Weights {
+		return false
+	}
+	if (a.Matrix == nil) != (b.Matrix == nil) || len(a.Matrix) != len(b.Matrix) {
+		return false
+	}
+	for i0 := range a.Matrix {
+		if (a.Matrix[i0] == nil) != (b.Matrix[i0] == nil) || len(a.Matrix[i0]) != len(b.Matrix[i0]) {
+			return false
+		}
+		for i1 := range a.Matrix[i0] {
+			if a.Matrix[i0][i1] != b.Matrix[i0][i1] {
+				return false
+			}
+		}
+	}
+	if (a.Optional == nil) != (b.Optional == nil) {
+		return false
+	}
+	if a.Optional != nil {
+		if (*a.Optional) != (*b.Optional) {
+			return false
+		}
+	}
+	return true
+}
+
+func l8reflectCloneGenInfo(src *GenInfo) *GenInfo {
+	if src == nil {
+		return nil
+	}
+	dst := &GenInfo{}
+	dst.Vendor = src.Vendor
+	if src.Serial != nil {
+		dst.Serial = make([]byte, len(src.Serial))
+		copy(dst.Serial, src.Serial)
+	}
+	return dst
+}
+
+func l8reflectEqualGenInfo(a, b *GenInfo) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Vendor != b.Vendor {
+		return false
+	}
+	if (a.Serial == nil) != (b.Serial == nil) || !bytes.Equal(a.Serial, b.Serial) {
+		return false
+	}
+	return true
+}
+
+func l8reflectCloneGenPort(src *GenPort) *GenPort {
+	if src == nil {
+		return nil
+	}
+	dst := &GenPort{}
+	dst.Name = src.Name
+	dst.Status = src.Status
+	if src.Speed != nil {
+		var p0 int64
+		p0 = (*src.Speed)
+		dst.Speed = &p0
+	}
+	return dst
+}
+
+func l8reflectEqualGenPort(a, b *GenPort) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Name != b.Name {
+		return false
+	}
+	if a.Status != b.Status {
+		return false
+	}
+	if (a.Speed == nil) != (b.Speed == nil) {
+		return false
+	}
+	if a.Speed != nil {
+		if (*a.Speed) != (*b.Speed) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,227 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+	"github.com/saichler/l8reflect/go/reflect/helping"
+)
+
+//go:generate go run ../cmd/l8reflect-gen -type GenDevice -output generated_l8reflect_test.go
+
+type GenStatus int32
+
+type GenInfo struct {
+	Vendor string
+	Serial []byte
+}
+
+type GenPort struct {
+	Name   string
+	Status GenStatus
+	Speed  *int64
+}
+
+type GenDevice struct {
+	Id            string
+	Status        GenStatus
+	Info          *GenInfo
+	Location      GenInfo
+	Ports         []*GenPort
+	PortsByName   map[string]*GenPort
+	Groups        map[string][]*GenPort
+	Tags          []string
+	Labels        map[string]string
+	Slots         [2]*GenPort
+	Weights       [3]float64
+	Matrix        [][]int32
+	Optional      *string
+	XXX_sizecache int32
+	state         int
+}
+
+// GenDeviceReflect has the layout of GenDevice without its generated methods,
+// so it is cloned and compared by reflection.
+type GenDeviceReflect GenDevice
+
+func newGenDevice() *GenDevice {
+	speed := int64(100)
+	optional := "opt"
+	port0 := &GenPort{Name: "eth0", Status: 1, Speed: &speed}
+	port1 := &GenPort{Name: "eth1", Status: 2}
+	return &GenDevice{
+		Id:          "d1",
+		Status:      3,
+		Info:        &GenInfo{Vendor: "v", Serial: []byte{1, 2}},
+		Location:    GenInfo{Vendor: "l"},
+		Ports:       []*GenPort{port0, port1},
+		PortsByName: map[string]*GenPort{"eth0": port0},
+		Groups:      map[string][]*GenPort{"all": {port0, port1}},
+		Tags:        []string{"a", "b"},
+		Labels:      map[string]string{"k": "v"},
+		Slots:       [2]*GenPort{port1, nil},
+		Weights:     [3]float64{1, 2, 3},
+		Matrix:      [][]int32{{1, 2}, nil},
+		Optional:    &optional,
+		state:       7,
+	}
+}
+
+func TestGeneratedClone(t *testing.T) {
+	device := newGenDevice()
+	clone := cloning.NewCloner().Clone(device).(*GenDevice)
+	reflected := cloning.NewCloner().Clone((*GenDeviceReflect)(device)).(*GenDeviceReflect)
+	if !cloning.NewDeepEqual().Equal((*GenDeviceReflect)(clone), reflected) {
+		log.Fail(t, "Expected generated clone to match the reflective clone")
+		return
+	}
+	if clone.Info == device.Info || clone.Ports[0] == device.Ports[0] || clone.Optional == device.Optional ||
+		&clone.Info.Serial[0] == &device.Info.Serial[0] {
+		log.Fail(t, "Expected generated clone to be deep")
+		return
+	}
+	if clone.state != 0 || *clone.Ports[0].Speed != 100 {
+		log.Fail(t, "Expected generated clone to follow the skip rules")
+		return
+	}
+}
+
+func TestGeneratedEqual(t *testing.T) {
+	de := cloning.NewDeepEqual()
+	device := newGenDevice()
+	other := newGenDevice()
+	other.state = 1
+	if !de.Equal(device, other) {
+		log.Fail(t, "Expected generated equal to ignore skipped fields")
+		return
+	}
+	changes := []func(*GenDevice){
+		func(d *GenDevice) { d.Status = 9 },
+		func(d *GenDevice) { d.Info.Serial[1] = 9 },
+		func(d *GenDevice) { d.Ports[1].Name = "x" },
+		func(d *GenDevice) { *d.Ports[0].Speed = 1 },
+		func(d *GenDevice) { delete(d.PortsByName, "eth0") },
+		func(d *GenDevice) { d.Groups["all"] = d.Groups["all"][:1] },
+		func(d *GenDevice) { d.Labels["k"] = "x" },
+		func(d *GenDevice) { d.Slots[1] = &GenPort{} },
+		func(d *GenDevice) { d.Weights[2] = 0 },
+		func(d *GenDevice) { d.Matrix[1] = []int32{} },
+		func(d *GenDevice) { d.Optional = nil },
+		func(d *GenDevice) { d.Tags = nil },
+	}
+	for i, change := range changes {
+		other = newGenDevice()
+		change(other)
+		if de.Equal(device, other) {
+			log.Fail(t, "Expected generated equal to detect change ", i)
+			return
+		}
+		if de.Equal((*GenDeviceReflect)(device), (*GenDeviceReflect)(other)) {
+			log.Fail(t, "Expected reflective equal to detect change ", i)
+			return
+		}
+	}
+}
+
+func TestGeneratedEqualOptions(t *testing.T) {
+	device := newGenDevice()
+	other := newGenDevice()
+	other.Weights[0] += 1e-9
+	other.Tags = []string{"b", "a"}
+	other.Info.Vendor = "other"
+	de := cloning.NewDeepEqual()
+	if de.Equal(device, other) {
+		log.Fail(t, "Expected exact equal to detect the changes")
+		return
+	}
+	err := de.SetOptions(&cloning.EqualOptions{FloatAbsTolerance: 1e-6, IgnorePaths: []string{"gendevice.info.vendor"}})
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	de.SetSliceOrderPolicy(helping.SliceOrderPolicyFunc(func(structType reflect.Type, field reflect.StructField) (bool, []string) {
+		return field.Name == "Tags", nil
+	}))
+	if !de.Equal(device, other) {
+		log.Fail(t, "Expected the options and order policy to apply to generated types")
+		return
+	}
+	de = cloning.NewDeepEqual()
+	de.SetFieldPolicy(helping.NewNamePolicy("Weights", "Tags", "Vendor"))
+	if !de.Equal(device, other) {
+		log.Fail(t, "Expected the field policy to apply to generated types")
+		return
+	}
+}
+
+func BenchmarkCloneReflective(b *testing.B) {
+	cloner := cloning.NewCloner()
+	device := (*GenDeviceReflect)(newGenDevice())
+	for i := 0; i < b.N; i++ {
+		cloner.Clone(device)
+	}
+}
+
+func BenchmarkCloneGenerated(b *testing.B) {
+	cloner := cloning.NewCloner()
+	device := newGenDevice()
+	for i := 0; i < b.N; i++ {
+		cloner.Clone(device)
+	}
+}
+
+func BenchmarkEqualReflective(b *testing.B) {
+	de := cloning.NewDeepEqual()
+	aside := (*GenDeviceReflect)(newGenDevice())
+	zside := (*GenDeviceReflect)(newGenDevice())
+	for i := 0; i < b.N; i++ {
+		de.Equal(aside, zside)
+	}
+}
+
+func BenchmarkEqualGenerated(b *testing.B) {
+	de := cloning.NewDeepEqual()
+	aside := newGenDevice()
+	zside := newGenDevice()
+	for i := 0; i < b.N; i++ {
+		de.Equal(aside, zside)
+	}
+}
+
+func TestGeneratedLimits(t *testing.T) {
+	device := newGenDevice()
+	for i := 0; i < 100; i++ {
+		device.Tags = append(device.Tags, "tag")
+	}
+	limits := &cloning.Limits{MaxElements: 50}
+	cloner := cloning.NewCloner()
+	cloner.SetLimits(limits)
+	_, err := cloner.CloneWithError(device)
+	limitErr := &cloning.LimitError{}
+	if !errors.As(err, &limitErr) || limitErr.Limit != "elements" {
+		log.Fail(t, "Expected the limits to apply to generated clones, got ", err)
+		return
+	}
+	de := cloning.NewDeepEqual()
+	de.SetLimits(limits)
+	eq, err := de.EqualWithError(device, device)
+	if eq || !errors.As(err, &limitErr) || limitErr.Limit != "elements" {
+		log.Fail(t, "Expected the limits to apply to generated equals, got ", err)
+		return
+	}
+}
@@ -0,0 +1,265 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+	"github.com/saichler/l8reflect/go/reflect/generating"
+	"github.com/saichler/l8reflect/go/reflect/introspecting"
+	"github.com/saichler/l8types/go/ifs"
+)
+
+type RandomPort struct {
+	Name   string
+	Speed  int64
+	Status MapStatus
+}
+
+type RandomLink struct {
+	Slot int32
+	Peer string
+}
+
+type RandomDevice struct {
+	Id     string
+	Uptime uint32
+	Load   float64
+	Active bool
+	Digest [4]byte
+	Labels map[int32]string
+	Ports  []*RandomPort
+	PortBy map[string]*RandomPort
+	Links  []RandomLink
+	Secret string
+}
+
+type RandomSensor struct {
+	Id      string
+	Level   int8
+	Reading float64
+	Counts  []uint16
+	Name    string
+	Tags    []string
+	Attrs   map[string]int32
+	Data    []byte
+}
+
+type RandomAlarm struct {
+	Id       string
+	Status   MapStatus
+	Severity MapStatus
+}
+
+// newRandomResources returns resources with RandomDevice and RandomPort keyed by Id and Name,
+// and RandomLink unique by Slot.
+func newRandomResources() ifs.IResources {
+	res := newLocalResources(map[interface{}]string{&RandomDevice{}: "Id", &RandomPort{}: "Name"})
+	in := res.Introspector().(*introspecting.Introspector)
+	in.AddUniqueKeyDecorator(&RandomLink{}, "Slot")
+	in.AddSkipDecorator(&RandomDevice{}, "Secret")
+	return res
+}
+
+func TestGenerateReproducible(t *testing.T) {
+	generator := generating.NewGenerator(newRandomResources())
+	options := &generating.GenerateOptions{Seed: 42}
+	first, err := generator.Generate("RandomDevice", options)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	second, err := generator.Generate("RandomDevice", options)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	deepEqual := cloning.NewDeepEqual()
+	if !deepEqual.Equal(first, second) {
+		log.Fail(t, "Expected the same seed to generate the same instance")
+		return
+	}
+	other, err := generator.Generate("RandomDevice", &generating.GenerateOptions{Seed: 7})
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if deepEqual.Equal(first, other) {
+		log.Fail(t, "Expected different seeds to generate different instances")
+		return
+	}
+}
+
+func TestGenerateValues(t *testing.T) {
+	generator := generating.NewGenerator(newRandomResources())
+	options := &generating.GenerateOptions{Seed: 3, MinLength: 2, MaxLength: 4, StringLength: 5}
+	for seed := int64(0); seed < 20; seed++ {
+		options.Seed = seed
+		any, err := generator.Generate("RandomDevice", options)
+		if err != nil {
+			log.Fail(t, err.Error())
+			return
+		}
+		device := any.(*RandomDevice)
+		if len(device.Id) != 5 {
+			log.Fail(t, "Expected a string of length 5, got ", device.Id)
+			return
+		}
+		if device.Secret != "" {
+			log.Fail(t, "Expected the skipped field to be left zero")
+			return
+		}
+		if len(device.Labels) < 2 || len(device.Labels) > 4 {
+			log.Fail(t, "Expected 2 to 4 labels, got ", len(device.Labels))
+			return
+		}
+		if len(device.Ports) < 2 || len(device.Ports) > 4 {
+			log.Fail(t, "Expected 2 to 4 ports, got ", len(device.Ports))
+			return
+		}
+		names := make(map[string]bool)
+		for _, port := range device.Ports {
+			if port == nil || names[port.Name] {
+				log.Fail(t, "Expected ports with unique names")
+				return
+			}
+			names[port.Name] = true
+			if MapStatus_name[int32(port.Status)] == "" {
+				log.Fail(t, "Expected a named status, got ", int32(port.Status))
+				return
+			}
+		}
+		for key, port := range device.PortBy {
+			if port == nil || key != port.Name {
+				log.Fail(t, "Expected ports keyed by their primary key")
+				return
+			}
+		}
+		slots := make(map[int32]bool)
+		for _, link := range device.Links {
+			if slots[link.Slot] {
+				log.Fail(t, "Expected links with unique slots")
+				return
+			}
+			slots[link.Slot] = true
+		}
+	}
+}
+
+func TestGenerateMaxDepth(t *testing.T) {
+	generator := generating.NewGenerator(newRandomResources())
+	any, err := generator.Generate("RandomDevice", &generating.GenerateOptions{MaxDepth: 1})
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	device := any.(*RandomDevice)
+	if device.Id == "" || len(device.Labels) == 0 {
+		log.Fail(t, "Expected the root fields to be populated")
+		return
+	}
+	if device.Ports != nil || device.PortBy != nil || device.Links != nil {
+		log.Fail(t, "Expected nested structs beyond the max depth to be left nil")
+		return
+	}
+}
+
+func TestGenerateUnknownType(t *testing.T) {
+	generator := generating.NewGenerator(newRandomResources())
+	_, err := generator.Generate("NoSuchType", nil)
+	if err == nil {
+		log.Fail(t, "Expected an error for an unregistered type")
+		return
+	}
+}
+
+func TestGenerateConstraints(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{&RandomSensor{}: "Id"})
+	in := res.Introspector().(*introspecting.Introspector)
+	if in.AddRangeDecorator(&RandomSensor{}, "Level", 5, 1) == nil ||
+		in.AddLengthDecorator(&RandomSensor{}, "Name", -1, 3) == nil {
+		log.Fail(t, "Expected an error for invalid bounds")
+		return
+	}
+	in.AddRangeDecorator(&RandomSensor{}, "Level", 0, 100)
+	in.AddRangeDecorator(&RandomSensor{}, "Level", -5, 5)
+	in.AddRangeDecorator(&RandomSensor{}, "Reading", -1.5, 1.5)
+	in.AddRangeDecorator(&RandomSensor{}, "Counts", 10, 20)
+	in.AddRangeDecorator(&RandomSensor{}, "Attrs", 7, 9)
+	in.AddLengthDecorator(&RandomSensor{}, "Name", 2, 3)
+	in.AddLengthDecorator(&RandomSensor{}, "Tags", 5, 6)
+	in.AddLengthDecorator(&RandomSensor{}, "Attrs", 4, 4)
+	in.AddLengthDecorator(&RandomSensor{}, "Data", 1, 2)
+	generator := generating.NewGenerator(res)
+	for seed := int64(0); seed < 20; seed++ {
+		any, err := generator.Generate("RandomSensor", &generating.GenerateOptions{Seed: seed, MaxLength: 2, StringLength: 8})
+		if err != nil {
+			log.Fail(t, err.Error())
+			return
+		}
+		sensor := any.(*RandomSensor)
+		if sensor.Level < -5 || sensor.Level > 5 || sensor.Reading < -1.5 || sensor.Reading > 1.5 {
+			log.Fail(t, "Expected the values within their range, got ", sensor.Level, " ", sensor.Reading)
+			return
+		}
+		for _, count := range sensor.Counts {
+			if count < 10 || count > 20 {
+				log.Fail(t, "Expected the slice elements within their range, got ", count)
+				return
+			}
+		}
+		if len(sensor.Name) < 2 || len(sensor.Name) > 3 || len(sensor.Tags) < 5 || len(sensor.Tags) > 6 ||
+			len(sensor.Attrs) != 4 || len(sensor.Data) < 1 || len(sensor.Data) > 2 {
+			log.Fail(t, "Expected the lengths within their range")
+			return
+		}
+		for _, tag := range sensor.Tags {
+			if len(tag) != 8 {
+				log.Fail(t, "Expected the length range to bound the slice, not its elements")
+				return
+			}
+		}
+		for key, value := range sensor.Attrs {
+			if value < 7 || value > 9 || len(key) != 8 {
+				log.Fail(t, "Expected the range to bound the map values, not its keys")
+				return
+			}
+		}
+		if len(sensor.Id) != 8 {
+			log.Fail(t, "Expected unconstrained fields to use the options")
+			return
+		}
+	}
+}
+
+func TestGenerateEnumOutOfRange(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{&RandomAlarm{}: "Id"})
+	in := res.Introspector().(*introspecting.Introspector)
+	in.AddRangeDecorator(&RandomAlarm{}, "Status", 5, 9)
+	in.AddRangeDecorator(&RandomAlarm{}, "Severity", -3, -1)
+	generator := generating.NewGenerator(res)
+	for seed := int64(0); seed < 10; seed++ {
+		any, err := generator.Generate("RandomAlarm", &generating.GenerateOptions{Seed: seed})
+		if err != nil {
+			log.Fail(t, err.Error())
+			return
+		}
+		alarm := any.(*RandomAlarm)
+		if alarm.Status != MapStatus_Down || alarm.Severity != MapStatus_Unknown {
+			log.Fail(t, "Expected the named values nearest to the ranges, got ", int32(alarm.Status), " ", int32(alarm.Severity))
+			return
+		}
+	}
+}
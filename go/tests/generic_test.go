@@ -0,0 +1,136 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+	"github.com/saichler/l8reflect/go/reflect/helping"
+	"github.com/saichler/l8reflect/go/reflect/properties"
+	"github.com/saichler/l8reflect/go/reflect/updating"
+)
+
+type GenericPage[T any] struct {
+	Id    string
+	Items []*T
+	Total int32
+}
+
+type GenericItem struct {
+	Name string
+}
+
+type GenericHolder struct {
+	Id   string
+	Page *GenericPage[GenericItem]
+}
+
+func TestCanonicalTypeName(t *testing.T) {
+	name := reflect.TypeOf(GenericPage[GenericItem]{}).Name()
+	if helping.CanonicalTypeName(name) != "GenericPage_GenericItem" {
+		log.Fail(t, "Unexpected canonical name ", helping.CanonicalTypeName(name), " for ", name)
+		return
+	}
+	if helping.CanonicalTypeName("GenericPage_GenericItem") != "GenericPage_GenericItem" {
+		log.Fail(t, "Expected canonical names to map to themselves")
+		return
+	}
+	if helping.CanonicalTypeName("Pair[string,github.com/x/model.Device]") != "Pair_string_Device" {
+		log.Fail(t, "Unexpected canonical name for multiple type arguments")
+		return
+	}
+}
+
+func TestGenericIntrospectAndLookup(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{&GenericPage[GenericItem]{}: "Id"})
+	_, ok := res.Introspector().Node("genericpage_genericitem.items.name")
+	if !ok {
+		log.Fail(t, "Expected a path-safe node key for the generic type")
+		return
+	}
+	_, ok = res.Introspector().NodeByTypeName("GenericPage_GenericItem")
+	if !ok {
+		log.Fail(t, "Expected lookup by canonical name")
+		return
+	}
+	_, ok = res.Introspector().NodeByType(reflect.TypeOf(GenericPage[GenericItem]{}))
+	if !ok {
+		log.Fail(t, "Expected lookup by reflect type")
+		return
+	}
+}
+
+func TestGenericPropertyPaths(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{&GenericPage[GenericItem]{}: "Id", &GenericHolder{}: "Id"})
+	page := &GenericPage[GenericItem]{Id: "p1", Items: []*GenericItem{{Name: "i0"}}}
+
+	prop, err := properties.PropertyOf("genericpage_genericitem.items<{2}0>.name", res)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	v, err := prop.Get(page)
+	if err != nil || v != "i0" {
+		log.Fail(t, "Expected i0 but got ", v)
+		return
+	}
+
+	holder := &GenericHolder{Id: "h1"}
+	prop, err = properties.PropertyOf("genericholder.page.total", res)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	_, _, err = prop.Set(holder, int32(5))
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if holder.Page == nil || holder.Page.Total != 5 {
+		log.Fail(t, "Expected set to resolve through the generic wrapper")
+		return
+	}
+}
+
+func TestGenericUpdater(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{&GenericPage[GenericItem]{}: "Id"})
+	aside := &GenericPage[GenericItem]{Id: "p1", Items: []*GenericItem{{Name: "i0"}}}
+	zside := &GenericPage[GenericItem]{Id: "p1", Items: []*GenericItem{{Name: "i1"}}, Total: 1}
+	yside := cloning.NewCloner().Clone(aside).(*GenericPage[GenericItem])
+
+	upd := updating.NewUpdater(res, false, false)
+	err := upd.Update(aside, zside)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	for _, change := range upd.Changes() {
+		prop, err := properties.PropertyOf(change.PropertyId(), res)
+		if err != nil {
+			log.Fail(t, err.Error())
+			return
+		}
+		_, _, err = prop.Set(yside, change.NewValue())
+		if err != nil {
+			log.Fail(t, err.Error())
+			return
+		}
+	}
+	if !cloning.NewDeepEqual().Equal(yside, zside) {
+		log.Fail(t, "Expected generic property ids to round trip")
+		return
+	}
+}
@@ -0,0 +1,181 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+)
+
+type HashPort struct {
+	Name  string
+	Speed int64
+	Load  float64
+}
+
+type HashDevice struct {
+	Id     string
+	Ports  []*HashPort
+	Labels map[string]string
+	Extra  interface{}
+	Seen   int64
+	Tags   []string
+}
+
+func newHashDevice() *HashDevice {
+	device := &HashDevice{
+		Id:     "d1",
+		Ports:  []*HashPort{{Name: "eth0", Speed: 1, Load: 0.5}, {Name: "eth1", Speed: 10}},
+		Labels: make(map[string]string),
+		Extra:  int32(7),
+		Seen:   100,
+	}
+	for i := 0; i < 20; i++ {
+		device.Labels["key"+strconv.Itoa(i)] = strconv.Itoa(i)
+	}
+	return device
+}
+
+func TestHasherEqualValues(t *testing.T) {
+	hasher := cloning.NewHasher(nil)
+	aSide := newHashDevice()
+	zSide := cloning.NewCloner().Clone(aSide).(*HashDevice)
+	if hasher.Hash64(aSide) != hasher.Hash64(zSide) || hasher.Hash128(aSide) != hasher.Hash128(zSide) {
+		log.Fail(t, "Expected equal values to have the same hash")
+		return
+	}
+	zSide.Labels = make(map[string]string)
+	for i := 19; i >= 0; i-- {
+		zSide.Labels["key"+strconv.Itoa(i)] = strconv.Itoa(i)
+	}
+	if hasher.Hash64(aSide) != hasher.Hash64(zSide) {
+		log.Fail(t, "Expected the map order not to change the hash")
+		return
+	}
+	zSide.Ports[0].Load = math.Copysign(0, -1)
+	aSide.Ports[0].Load = 0
+	if hasher.Hash64(aSide) != hasher.Hash64(zSide) {
+		log.Fail(t, "Expected 0 and -0 to have the same hash")
+		return
+	}
+}
+
+func TestHasherDifferentValues(t *testing.T) {
+	hasher := cloning.NewHasher(nil)
+	base := hasher.Hash64(newHashDevice())
+	changes := []func(*HashDevice){
+		func(d *HashDevice) { d.Id = "d2" },
+		func(d *HashDevice) { d.Ports[1].Speed = 100 },
+		func(d *HashDevice) { d.Ports = d.Ports[:1] },
+		func(d *HashDevice) { d.Ports[0], d.Ports[1] = d.Ports[1], d.Ports[0] },
+		func(d *HashDevice) { d.Labels["key0"] = "x" },
+		func(d *HashDevice) { d.Extra = int64(7) },
+		func(d *HashDevice) { d.Tags = []string{} },
+	}
+	for i, change := range changes {
+		device := newHashDevice()
+		change(device)
+		if hasher.Hash64(device) == base {
+			log.Fail(t, "Expected change ", i, " to change the hash")
+			return
+		}
+	}
+}
+
+func TestHasherEqualOptions(t *testing.T) {
+	deepEqual := cloning.NewDeepEqual()
+	err := deepEqual.SetOptions(&cloning.EqualOptions{
+		NaNEqual:    true,
+		NilAsEmpty:  true,
+		IgnorePaths: []string{"hashdevice.seen", "hashdevice.ports.load"},
+	})
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	hasher := cloning.NewHasher(deepEqual)
+	aSide := newHashDevice()
+	zSide := newHashDevice()
+	zSide.Seen = 200
+	zSide.Ports[1].Load = 3
+	zSide.Tags = []string{}
+	aSide.Extra = math.NaN()
+	zSide.Extra = math.NaN()
+	if !deepEqual.Equal(aSide, zSide) || hasher.Hash64(aSide) != hasher.Hash64(zSide) {
+		log.Fail(t, "Expected values equal with the options to have the same hash")
+		return
+	}
+}
+
+func TestHasherUnorderedSlices(t *testing.T) {
+	deepEqual := newOrderDeepEqual(t)
+	if deepEqual == nil {
+		return
+	}
+	hasher := cloning.NewHasher(deepEqual)
+	zSide := newOrderDevice()
+	zSide.Interfaces[0], zSide.Interfaces[2] = zSide.Interfaces[2], zSide.Interfaces[0]
+	zSide.Tags = []string{"edge", "core", "core"}
+	if hasher.Hash64(newOrderDevice()) != hasher.Hash64(zSide) {
+		log.Fail(t, "Expected reordered unordered slices to have the same hash")
+		return
+	}
+	zSide.Path = []string{"b", "a"}
+	if hasher.Hash64(newOrderDevice()) == hasher.Hash64(zSide) {
+		log.Fail(t, "Expected reordered ordered slices to change the hash")
+		return
+	}
+}
+
+func TestHasherCycles(t *testing.T) {
+	hasher := cloning.NewHasher(nil)
+	if hasher.Hash64(newCycleTree("a", "b", "c")) != hasher.Hash64(newCycleTree("a", "b", "c")) {
+		log.Fail(t, "Expected equal cyclic graphs to have the same hash")
+		return
+	}
+	if hasher.Hash64(newCycleTree("a", "b", "c")) == hasher.Hash64(newCycleTree("a", "b", "d")) {
+		log.Fail(t, "Expected different cyclic graphs to have different hashes")
+		return
+	}
+	// a self loop and a two node loop are equal, but their cycles have different
+	// lengths, so their hashes are only required to be stable
+	self := &CycleNode{Name: "n"}
+	self.Next = self
+	pair := &CycleNode{Name: "n", Next: &CycleNode{Name: "n"}}
+	pair.Next.Next = pair
+	if !cloning.NewDeepEqual().Equal(self, pair) {
+		log.Fail(t, "Expected the self loop and the two node loop to be equal")
+		return
+	}
+	otherSelf := &CycleNode{Name: "n"}
+	otherSelf.Next = otherSelf
+	if hasher.Hash64(self) != hasher.Hash64(otherSelf) || hasher.Hash64(pair) != hasher.Hash64(pair) {
+		log.Fail(t, "Expected cycles of the same shape to have the same hash")
+		return
+	}
+}
+
+// TestHasherStable pins the hash of a value, so a change of the encoding is noticed.
+func TestHasherStable(t *testing.T) {
+	hash := cloning.NewHasher(nil).Hash64(&HashPort{Name: "eth0", Speed: 1, Load: 0.5})
+	if hash != hasherGolden {
+		log.Fail(t, "Expected the hash to be stable, got ", hash)
+		return
+	}
+}
+
+const hasherGolden = uint64(3125320971006811104)
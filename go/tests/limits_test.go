@@ -0,0 +1,224 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+	"github.com/saichler/l8reflect/go/reflect/merging"
+	"github.com/saichler/l8reflect/go/reflect/updating"
+)
+
+type LimitChain struct {
+	Name string
+	Next *LimitChain
+}
+
+type LimitDetail struct {
+	Name string
+}
+
+type LimitSub struct {
+	Detail *LimitDetail
+}
+
+type LimitInventory struct {
+	Id    string
+	Items map[string]string
+	Notes []string
+	Sub   *LimitSub
+}
+
+type LimitShared struct {
+	Left  *LimitInventory
+	Right *LimitInventory
+	Notes []string
+	Copy  []string
+}
+
+func newLimitChain(length int) *LimitChain {
+	var chain *LimitChain
+	for i := 0; i < length; i++ {
+		chain = &LimitChain{Name: strconv.Itoa(i), Next: chain}
+	}
+	return chain
+}
+
+func newLimitInventory(items int) *LimitInventory {
+	inventory := &LimitInventory{Id: "inv", Items: make(map[string]string), Notes: []string{"short", "short"}}
+	for i := 0; i < items; i++ {
+		inventory.Items["item"+strconv.Itoa(i)] = "value"
+	}
+	return inventory
+}
+
+// expectLimitError checks that err is a LimitError of the given limit and property id.
+func expectLimitError(t *testing.T, err error, limit, propertyId string) bool {
+	limitErr := &cloning.LimitError{}
+	if !errors.As(err, &limitErr) {
+		log.Fail(t, "Expected a limit error, got ", err)
+		return false
+	}
+	if limitErr.Limit != limit || limitErr.PropertyId != propertyId {
+		log.Fail(t, "Expected ", limit, " at ", propertyId, ", got ", limitErr.Error())
+		return false
+	}
+	return true
+}
+
+func TestLimitsCloneDepth(t *testing.T) {
+	cloner := cloning.NewCloner()
+	cloner.SetLimits(&cloning.Limits{MaxDepth: 4})
+	_, err := cloner.CloneWithError(newLimitChain(10))
+	if !expectLimitError(t, err, "depth", "limitchain.next.next.next.next.name") {
+		return
+	}
+	clone, err := cloner.CloneWithError(newLimitChain(3))
+	if err != nil || clone.(*LimitChain).Next.Next.Name != "0" {
+		log.Fail(t, "Expected a chain within the limits to be cloned")
+		return
+	}
+	defer func() {
+		if _, ok := recover().(*cloning.LimitError); !ok {
+			log.Fail(t, "Expected Clone to panic with a limit error")
+		}
+	}()
+	cloner.Clone(newLimitChain(10))
+}
+
+func TestLimitsCloneElementsAndBytes(t *testing.T) {
+	cloner := cloning.NewCloner()
+	cloner.SetParallel(10, 4)
+	cloner.SetLimits(&cloning.Limits{MaxElements: 50})
+	_, err := cloner.CloneWithError(newLimitInventory(100))
+	if !expectLimitError(t, err, "elements", "limitinventory.items") {
+		return
+	}
+	cloner.SetLimits(&cloning.Limits{MaxBytes: 1000})
+	inventory := newLimitInventory(0)
+	inventory.Notes[1] = strings.Repeat("x", 2000)
+	_, err = cloner.CloneWithError(inventory)
+	if !expectLimitError(t, err, "bytes", "limitinventory.notes<{2}1>") {
+		return
+	}
+	err = cloner.CopyInto(&LimitInventory{}, inventory)
+	if !expectLimitError(t, err, "bytes", "limitinventory.notes<{2}1>") {
+		return
+	}
+	_, err = cloner.CloneMask(inventory, "limitinventory.notes")
+	if !expectLimitError(t, err, "bytes", "limitinventory.notes<{2}1>") {
+		return
+	}
+	inventory.Notes[1] = "short"
+	clone, err := cloner.CloneWithError(inventory)
+	if err != nil || clone.(*LimitInventory).Notes[1] != "short" {
+		log.Fail(t, "Expected an inventory within the limits to be cloned")
+		return
+	}
+}
+
+func TestLimitsDeepEqual(t *testing.T) {
+	deepEqual := cloning.NewDeepEqual()
+	deepEqual.SetLimits(&cloning.Limits{MaxElements: 150})
+	eq, err := deepEqual.EqualWithError(newLimitInventory(100), newLimitInventory(100))
+	if eq || !expectLimitError(t, err, "elements", "limitinventory.items") {
+		return
+	}
+	eq, err = deepEqual.EqualWithError(newLimitInventory(50), newLimitInventory(50))
+	if !eq || err != nil {
+		log.Fail(t, "Expected inventories within the limits to be equal")
+		return
+	}
+	deepEqual.SetLimits(&cloning.Limits{MaxDepth: 3})
+	_, err = deepEqual.EqualWithError(newLimitChain(10), newLimitChain(10))
+	if !expectLimitError(t, err, "depth", "limitchain.next.next.next.name") {
+		return
+	}
+}
+
+func TestLimitsUpdater(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{&LimitInventory{}: "Id"})
+	upd := updating.NewUpdater(res, false, false)
+	upd.SetLimits(&cloning.Limits{MaxElements: 50})
+	err := upd.Update(newLimitInventory(0), newLimitInventory(100))
+	if !expectLimitError(t, err, "elements", "limitinventory.items") {
+		return
+	}
+
+	upd = updating.NewUpdater(res, false, false)
+	upd.SetLimits(&cloning.Limits{MaxDepth: 2})
+	newInventory := newLimitInventory(0)
+	newInventory.Sub = &LimitSub{Detail: &LimitDetail{Name: "deep"}}
+	err = upd.Update(newLimitInventory(0), newInventory)
+	if !expectLimitError(t, err, "depth", "limitinventory.sub.detail.name") {
+		return
+	}
+
+	upd = updating.NewUpdater(res, false, false)
+	upd.SetLimits(&cloning.Limits{MaxDepth: 3, MaxElements: 50})
+	old := newLimitInventory(0)
+	err = upd.Update(old, newInventory)
+	if err != nil || old.Sub == nil || old.Sub.Detail.Name != "deep" {
+		log.Fail(t, "Expected an update within the limits to be applied, got ", err)
+		return
+	}
+}
+
+func TestLimitsSharedReferences(t *testing.T) {
+	inventory := newLimitInventory(60)
+	notes := make([]string, 30)
+	shared := &LimitShared{Left: inventory, Right: inventory, Notes: notes, Copy: notes}
+	limits := &cloning.Limits{MaxElements: 100}
+	if limits.Check(shared) != nil {
+		log.Fail(t, "Expected Check to count shared references once")
+		return
+	}
+	cloner := cloning.NewCloner()
+	cloner.SetLimits(limits)
+	clone, err := cloner.CloneWithError(shared)
+	if err != nil || len(clone.(*LimitShared).Copy) != 30 {
+		log.Fail(t, "Expected Clone to count shared references once like Check, got ", err)
+		return
+	}
+	separate := &LimitShared{Left: inventory, Right: newLimitInventory(60)}
+	if !expectLimitError(t, limits.Check(separate), "elements", "limitshared.right.items") {
+		return
+	}
+	_, err = cloner.CloneWithError(separate)
+	if !expectLimitError(t, err, "elements", "limitshared.right.items") {
+		return
+	}
+}
+
+func TestLimitsMapperAndMerger(t *testing.T) {
+	mapper, _ := newMapper(t)
+	mapper.SetLimits(&cloning.Limits{MaxBytes: 1})
+	_, err := mapper.Map(newMapDeviceDto(), &MapDevice{})
+	limitErr := &cloning.LimitError{}
+	if !errors.As(err, &limitErr) {
+		log.Fail(t, "Expected the Mapper to return a limit error, got ", err)
+		return
+	}
+	merger := merging.NewMerger()
+	merger.SetLimits(&cloning.Limits{MaxElements: 50})
+	_, err = merger.Merge(&merging.Source{Name: "a", Value: newLimitInventory(100)})
+	if !errors.As(err, &limitErr) || limitErr.Limit != "elements" {
+		log.Fail(t, "Expected the Merger to return a limit error, got ", err)
+		return
+	}
+}
@@ -0,0 +1,207 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+	"github.com/saichler/l8reflect/go/reflect/properties"
+	"github.com/saichler/l8reflect/go/reflect/updating"
+)
+
+type KeyColor int32
+
+type KeyLabel string
+
+type KeyPair struct {
+	Zone string
+	Slot int32
+}
+
+type KeyedEntry struct {
+	Name string
+}
+
+type KeyedModel struct {
+	Id      string
+	ByColor map[KeyColor]string
+	ByLabel map[KeyLabel]*KeyedEntry
+	ByPair  map[KeyPair]*KeyedEntry
+}
+
+func newKeyedModel() *KeyedModel {
+	return &KeyedModel{
+		Id:      "k1",
+		ByColor: map[KeyColor]string{1: "red", 2: "green"},
+		ByLabel: map[KeyLabel]*KeyedEntry{"l1": {Name: "first"}},
+		ByPair:  map[KeyPair]*KeyedEntry{{Zone: "z1", Slot: 2}: {Name: "pair"}},
+	}
+}
+
+func TestMapKeyStructPath(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{&KeyedModel{}: "Id"})
+	model := newKeyedModel()
+
+	prop, err := properties.PropertyOf("keyedmodel.bypair<{24}z1::{5}2>.name", res)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	v, err := prop.Get(model)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if v != "pair" {
+		log.Fail(t, "Expected pair but got ", v)
+		return
+	}
+
+	_, _, err = prop.Set(model, "updated")
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if model.ByPair[KeyPair{Zone: "z1", Slot: 2}].Name != "updated" {
+		log.Fail(t, "Expected struct keyed entry to be updated")
+		return
+	}
+}
+
+func TestMapKeyNamedTypePath(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{&KeyedModel{}: "Id"})
+	model := newKeyedModel()
+
+	prop, err := properties.PropertyOf("keyedmodel.bycolor<{5}3>", res)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	_, _, err = prop.Set(model, "blue")
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if model.ByColor[KeyColor(3)] != "blue" {
+		log.Fail(t, "Expected enum keyed entry to be added")
+		return
+	}
+
+	prop, err = properties.PropertyOf("keyedmodel.bylabel<{24}l1>.name", res)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	v, err := prop.Get(model)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if v != "first" {
+		log.Fail(t, "Expected first but got ", v)
+		return
+	}
+}
+
+func TestMapKeyUpdaterRoundTrip(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{&KeyedModel{}: "Id"})
+	aside := newKeyedModel()
+	zside := cloning.NewCloner().Clone(aside).(*KeyedModel)
+	yside := cloning.NewCloner().Clone(aside).(*KeyedModel)
+	zside.ByColor[2] = "yellow"
+	zside.ByLabel["l1"].Name = "changed"
+	zside.ByPair[KeyPair{Zone: "z1", Slot: 2}].Name = "changed"
+	zside.ByPair[KeyPair{Zone: "z2", Slot: 7}] = &KeyedEntry{Name: "new"}
+
+	upd := updating.NewUpdater(res, false, false)
+	err := upd.Update(aside, zside)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if len(upd.Changes()) != 4 {
+		log.Fail(t, "Expected 4 changes but got ", len(upd.Changes()))
+		return
+	}
+
+	for _, change := range upd.Changes() {
+		prop, err := properties.PropertyOf(change.PropertyId(), res)
+		if err != nil {
+			log.Fail(t, err.Error())
+			return
+		}
+		_, _, err = prop.Set(yside, change.NewValue())
+		if err != nil {
+			log.Fail(t, err.Error())
+			return
+		}
+	}
+	if !cloning.NewDeepEqual().Equal(yside, zside) {
+		log.Fail(t, "Expected changes with typed keys to round trip through PropertyId")
+		return
+	}
+}
+
+func TestMapKeyStructSeparatorInField(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{&KeyedModel{}: "Id"})
+	aside := newKeyedModel()
+	zside := cloning.NewCloner().Clone(aside).(*KeyedModel)
+	yside := cloning.NewCloner().Clone(aside).(*KeyedModel)
+	zside.ByPair[KeyPair{Zone: "a::b\\", Slot: 3}] = &KeyedEntry{Name: "escaped"}
+
+	upd := updating.NewUpdater(res, false, false)
+	err := upd.Update(aside, zside)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if len(upd.Changes()) != 1 {
+		log.Fail(t, "Expected 1 change but got ", len(upd.Changes()))
+		return
+	}
+	prop, err := properties.PropertyOf(upd.Changes()[0].PropertyId(), res)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	_, _, err = prop.Set(yside, upd.Changes()[0].NewValue())
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	entry, ok := yside.ByPair[KeyPair{Zone: "a::b\\", Slot: 3}]
+	if !ok || entry.Name != "escaped" {
+		log.Fail(t, "Expected a struct key containing :: to round trip through PropertyId")
+		return
+	}
+}
+
+func TestMapKeyUnknownEnumName(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{&KeyedModel{}: "Id"})
+	model := newKeyedModel()
+
+	prop, err := properties.PropertyOf("keyedmodel.bycolor<{24}purple>", res)
+	if err == nil {
+		_, _, err = prop.Set(model, "purple")
+	}
+	if err == nil {
+		log.Fail(t, "Expected an error for an unknown enum name")
+		return
+	}
+	if _, ok := model.ByColor[KeyColor(0)]; ok {
+		log.Fail(t, "Expected no entry under key 0")
+		return
+	}
+}
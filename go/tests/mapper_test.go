@@ -0,0 +1,215 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/introspecting"
+	"github.com/saichler/l8reflect/go/reflect/mapping"
+	"github.com/saichler/l8types/go/ifs"
+)
+
+type MapStatus int32
+
+const (
+	MapStatus_Unknown MapStatus = 0
+	MapStatus_Up      MapStatus = 1
+	MapStatus_Down    MapStatus = 2
+)
+
+var MapStatus_name = map[int32]string{0: "Unknown", 1: "Up", 2: "Down"}
+var MapStatus_value = map[string]int32{"Unknown": 0, "Up": 1, "Down": 2}
+
+func (this MapStatus) String() string {
+	return MapStatus_name[int32(this)]
+}
+
+type MapLocation struct {
+	Site string
+	Rack int32
+}
+
+type MapPortDto struct {
+	Name  string
+	Speed int32
+	Up    string
+}
+
+type MapDeviceDto struct {
+	Id       string
+	Name     string
+	Speed    int32
+	Status   string
+	Ports    []*MapPortDto
+	Counters map[string]int32
+	Location *MapLocation
+	Extra    string
+}
+
+type MapPort struct {
+	Name  string
+	Speed int64
+	Up    bool
+	Mtu   int32
+}
+
+type MapDevice struct {
+	DeviceId string
+	Name     string
+	Speed    int64
+	Status   MapStatus
+	Ports    []*MapPort
+	Counters map[string]int64
+	Location *MapLocation
+	Internal string
+}
+
+type MapParentDto struct {
+	Name     string
+	Children []*MapChildDto
+}
+
+type MapChildDto struct {
+	Name   string
+	Parent *MapParentDto
+}
+
+type MapParent struct {
+	Name     string
+	Children []*MapChild
+}
+
+type MapChild struct {
+	Name   string
+	Parent *MapParent
+}
+
+func newMapper(t *testing.T) (*mapping.Mapper, ifs.IResources) {
+	res := newLocalResources(map[interface{}]string{&MapDevice{}: "DeviceId", &MapDeviceDto{}: "Id"})
+	err := res.Introspector().(*introspecting.Introspector).AddAliasDecorator(&MapDevice{}, "DeviceId", "Id")
+	if err != nil {
+		log.Fail(t, err.Error())
+	}
+	mapper := mapping.NewMapper(res)
+	mapper.RegisterEnum(MapStatus(0), MapStatus_value)
+	return mapper, res
+}
+
+func newMapDeviceDto() *MapDeviceDto {
+	return &MapDeviceDto{
+		Id:       "d1",
+		Name:     "router",
+		Speed:    100,
+		Status:   "Down",
+		Ports:    []*MapPortDto{{Name: "eth0", Speed: 10, Up: "true"}, {Name: "eth1", Speed: 20, Up: "false"}},
+		Counters: map[string]int32{"in": 5},
+		Location: &MapLocation{Site: "dc1", Rack: 4},
+		Extra:    "extra",
+	}
+}
+
+func TestMapperDtoToModel(t *testing.T) {
+	mapper, _ := newMapper(t)
+	dto := newMapDeviceDto()
+	device := &MapDevice{Internal: "keep"}
+	report, err := mapper.Map(dto, device)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if device.DeviceId != "d1" || device.Name != "router" || device.Speed != 100 || device.Status != MapStatus_Down {
+		log.Fail(t, "Expected the leaves to be mapped, got ", device.DeviceId, " ", device.Speed, " ", device.Status)
+		return
+	}
+	if len(device.Ports) != 2 || device.Ports[1].Name != "eth1" || device.Ports[1].Speed != 20 ||
+		!device.Ports[0].Up || device.Ports[1].Up {
+		log.Fail(t, "Expected the ports to be mapped")
+		return
+	}
+	if device.Counters["in"] != 5 || device.Internal != "keep" {
+		log.Fail(t, "Expected the counters to be mapped and the unmapped field to be kept")
+		return
+	}
+	if device.Location == dto.Location || device.Location.Site != "dc1" {
+		log.Fail(t, "Expected the location to be cloned")
+		return
+	}
+	if !reflect.DeepEqual(report.UnmappedSource, []string{"mapdevicedto.extra"}) ||
+		!reflect.DeepEqual(report.UnmappedTarget, []string{"mapdevice.internal", "mapdevice.ports.mtu"}) {
+		log.Fail(t, "Unexpected report ", report.UnmappedSource, " ", report.UnmappedTarget)
+		return
+	}
+}
+
+func TestMapperModelToDto(t *testing.T) {
+	mapper, _ := newMapper(t)
+	mapper.AddAlias(&MapDeviceDto{}, "Extra", "Internal")
+	device := &MapDevice{DeviceId: "d2", Status: MapStatus_Up, Internal: "internal",
+		Ports: []*MapPort{{Name: "eth0", Up: true, Mtu: 1500}}}
+	dto := &MapDeviceDto{}
+	report, err := mapper.Map(device, dto)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if dto.Id != "d2" || dto.Status != "Up" || dto.Extra != "internal" || dto.Ports[0].Up != "true" {
+		log.Fail(t, "Expected the alias and enum to be mapped, got ", dto.Id, " ", dto.Status, " ", dto.Extra)
+		return
+	}
+	if len(report.UnmappedTarget) != 0 || !reflect.DeepEqual(report.UnmappedSource, []string{"mapdevice.ports.mtu"}) {
+		log.Fail(t, "Unexpected report ", report.UnmappedSource, " ", report.UnmappedTarget)
+		return
+	}
+}
+
+func TestMapperErrors(t *testing.T) {
+	mapper, _ := newMapper(t)
+	_, err := mapper.Map(newMapDeviceDto(), MapDevice{})
+	if err == nil {
+		log.Fail(t, "Expected an error for a target that is not a pointer")
+		return
+	}
+	_, err = mapper.Map("device", &MapDevice{})
+	if err == nil {
+		log.Fail(t, "Expected an error for a source that is not a struct")
+		return
+	}
+}
+
+func TestMapperCycles(t *testing.T) {
+	mapper, _ := newMapper(t)
+	source := &MapParentDto{Name: "parent"}
+	shared := &MapChildDto{Name: "shared", Parent: source}
+	source.Children = []*MapChildDto{shared, {Name: "other", Parent: source}, shared}
+	target := &MapParent{}
+	_, err := mapper.Map(source, target)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if len(target.Children) != 3 || target.Children[1].Name != "other" {
+		log.Fail(t, "Expected the children to be mapped")
+		return
+	}
+	if target.Children[0].Parent != target || target.Children[1].Parent != target {
+		log.Fail(t, "Expected the parent pointers to be mapped to the target")
+		return
+	}
+	if target.Children[0] != target.Children[2] {
+		log.Fail(t, "Expected a shared child to be mapped once")
+		return
+	}
+}
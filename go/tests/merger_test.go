@@ -0,0 +1,209 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/merging"
+)
+
+type MergeInterface struct {
+	Name   string
+	Speed  int64
+	Status string
+}
+
+type MergeLocation struct {
+	Site string
+	Rack int32
+}
+
+type MergeDevice struct {
+	Id         string
+	Vendor     string
+	Uptime     int64
+	Interfaces map[string]*MergeInterface
+	Tags       []string
+	Location   *MergeLocation
+}
+
+type MergeNode struct {
+	Name string
+	Site string
+	Peer *MergeNode
+}
+
+// newMergeSources returns the inventory of a device as reported by three collectors.
+func newMergeSources() []*merging.Source {
+	snmp := &MergeDevice{
+		Id:     "d1",
+		Vendor: "generic",
+		Uptime: 100,
+		Interfaces: map[string]*MergeInterface{
+			"eth0": {Name: "eth0", Speed: 1000, Status: "up"},
+			"eth1": {Name: "eth1", Speed: 1000},
+		},
+		Tags: []string{"snmp"},
+	}
+	netconf := &MergeDevice{
+		Id:     "d1",
+		Vendor: "cisco",
+		Uptime: 0,
+		Interfaces: map[string]*MergeInterface{
+			"eth1": {Name: "eth1", Speed: 10000, Status: "down"},
+			"eth2": {Name: "eth2", Speed: 100},
+		},
+		Tags:     []string{"netconf", "core"},
+		Location: &MergeLocation{Site: "dc1"},
+	}
+	manual := &MergeDevice{Id: "d1", Location: &MergeLocation{Rack: 7}}
+	return []*merging.Source{
+		{Name: "snmp", Priority: 1, Value: snmp},
+		{Name: "netconf", Priority: 5, Value: netconf},
+		{Name: "manual", Priority: 10, Value: manual},
+	}
+}
+
+func newMergeDeviceMerger(t *testing.T) *merging.Merger {
+	merger := merging.NewMerger()
+	strategies := map[string]merging.Strategy{
+		"mergedevice.vendor":           merging.HighestPriority,
+		"mergedevice.uptime":           merging.LastWins,
+		"mergedevice.interfaces":       merging.UnionKeys,
+		"mergedevice.interfaces.speed": merging.HighestPriority,
+		"mergedevice.tags":             merging.Concatenate,
+	}
+	for path, strategy := range strategies {
+		err := merger.SetStrategy(path, strategy)
+		if err != nil {
+			log.Fail(t, err.Error())
+		}
+	}
+	return merger
+}
+
+func TestMerge(t *testing.T) {
+	sources := newMergeSources()
+	result, err := newMergeDeviceMerger(t).Merge(sources...)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	device := result.Value.(*MergeDevice)
+	if device.Id != "d1" || device.Vendor != "cisco" || device.Uptime != 0 {
+		log.Fail(t, "Unexpected leaves ", device.Vendor, " ", device.Uptime)
+		return
+	}
+	if len(device.Interfaces) != 3 || device.Interfaces["eth0"].Speed != 1000 ||
+		device.Interfaces["eth1"].Speed != 10000 || device.Interfaces["eth1"].Status != "down" ||
+		device.Interfaces["eth2"].Speed != 100 {
+		log.Fail(t, "Expected the union of the interfaces")
+		return
+	}
+	if !reflect.DeepEqual(device.Tags, []string{"snmp", "netconf", "core"}) {
+		log.Fail(t, "Expected the concatenation of the tags, got ", device.Tags)
+		return
+	}
+	if device.Location.Site != "dc1" || device.Location.Rack != 7 {
+		log.Fail(t, "Expected the location to be merged field by field")
+		return
+	}
+	if device.Interfaces["eth0"] == sources[0].Value.(*MergeDevice).Interfaces["eth0"] {
+		log.Fail(t, "Expected the merged values to be cloned")
+		return
+	}
+	expected := map[string]int{
+		"mergedevice.id":                         0,
+		"mergedevice.vendor":                     1,
+		"mergedevice.uptime":                     2,
+		"mergedevice.interfaces<{24}eth1>.speed": 1,
+		"mergedevice.interfaces<{24}eth1>.name":  0,
+		"mergedevice.tags<{2}2>":                 1,
+		"mergedevice.location.site":              1,
+		"mergedevice.location.rack":              2,
+	}
+	for propertyId, source := range expected {
+		provider, ok := result.Sources[propertyId]
+		if !ok || provider != source {
+			log.Fail(t, "Expected ", propertyId, " from source ", source, ", got ", provider, " ", ok)
+			return
+		}
+	}
+	if _, ok := result.Sources["mergedevice.interfaces<{24}eth0>.status"]; !ok {
+		log.Fail(t, "Expected the status of eth0 to be provided")
+		return
+	}
+}
+
+func TestMergeErrors(t *testing.T) {
+	merger := merging.NewMerger()
+	merger.SetStrategy("mergedevice.vendor", merging.UnionKeys)
+	_, err := merger.Merge(newMergeSources()...)
+	if err == nil {
+		log.Fail(t, "Expected an error for a union of a string")
+		return
+	}
+	_, err = merging.NewMerger().Merge(&merging.Source{Name: "a", Value: &MergeDevice{}},
+		&merging.Source{Name: "b", Value: &MergeLocation{}})
+	if err == nil {
+		log.Fail(t, "Expected an error for sources of different types")
+		return
+	}
+	_, err = merging.NewMerger().Merge(&merging.Source{Name: "a", Value: (*MergeDevice)(nil)},
+		&merging.Source{Name: "b", Value: (*MergeDevice)(nil)})
+	if err == nil {
+		log.Fail(t, "Expected an error when every source is a nil pointer")
+		return
+	}
+	result, err := merging.NewMerger().Merge(&merging.Source{Name: "a", Value: (*MergeDevice)(nil)},
+		&merging.Source{Name: "b", Value: &MergeDevice{Vendor: "v"}})
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if result.Value.(*MergeDevice).Vendor != "v" || result.Sources["mergedevice.vendor"] != 1 {
+		log.Fail(t, "Expected a nil pointer source to be skipped")
+		return
+	}
+	if merging.NewMerger().SetDefaultStrategy(merging.Concatenate) == nil {
+		log.Fail(t, "Expected an error for a default strategy of slices")
+		return
+	}
+}
+
+func TestMergeCycles(t *testing.T) {
+	a1 := &MergeNode{Name: "a"}
+	a1.Peer = &MergeNode{Name: "b", Peer: a1}
+	a2 := &MergeNode{Site: "dc1"}
+	a2.Peer = &MergeNode{Site: "dc2", Peer: a2}
+	self := &MergeNode{}
+	self.Peer = self
+	result, err := merging.NewMerger().Merge(&merging.Source{Name: "names", Value: a1},
+		&merging.Source{Name: "sites", Value: a2}, &merging.Source{Name: "self", Value: self})
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	merged := result.Value.(*MergeNode)
+	if merged.Name != "a" || merged.Site != "dc1" || merged.Peer.Name != "b" || merged.Peer.Site != "dc2" {
+		log.Fail(t, "Expected the peers to be merged field by field")
+		return
+	}
+	if merged.Peer.Peer != merged {
+		log.Fail(t, "Expected the cycle to end at the merged node")
+		return
+	}
+}
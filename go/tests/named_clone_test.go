@@ -0,0 +1,143 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+)
+
+type NamedInt int
+type NamedInt8 int8
+type NamedInt16 int16
+type NamedSeverity int32
+type NamedInt64 int64
+type NamedUint uint
+type NamedUint8 uint8
+type NamedUint16 uint16
+type NamedUint32 uint32
+type NamedUint64 uint64
+type NamedFloat32 float32
+type NamedFloat64 float64
+type NamedComplex64 complex64
+type NamedComplex128 complex128
+type NamedBool bool
+type NamedLabel string
+type NamedLabels []NamedLabel
+type NamedScores map[NamedLabel]NamedFloat64
+type NamedPair [2]NamedInt
+type NamedRef *NamedLabel
+type NamedCallback func() int
+type NamedEvents chan NamedLabel
+
+type NamedModel struct {
+	Int        NamedInt
+	Int8       NamedInt8
+	Int16      NamedInt16
+	Severity   NamedSeverity
+	Int64      NamedInt64
+	Uint       NamedUint
+	Uint8      NamedUint8
+	Uint16     NamedUint16
+	Uint32     NamedUint32
+	Uint64     NamedUint64
+	Float32    NamedFloat32
+	Float64    NamedFloat64
+	Complex64  NamedComplex64
+	Complex128 NamedComplex128
+	Bool       NamedBool
+	Label      NamedLabel
+	Labels     NamedLabels
+	Scores     NamedScores
+	Pair       NamedPair
+	Ref        NamedRef
+	Callback   NamedCallback
+	Events     NamedEvents
+	Any        interface{}
+}
+
+func newNamedModel() *NamedModel {
+	label := NamedLabel("ref")
+	return &NamedModel{
+		Int: -1, Int8: -8, Int16: -16, Severity: 3, Int64: -64,
+		Uint: 1, Uint8: 8, Uint16: 16, Uint32: 32, Uint64: 64,
+		Float32: 1.5, Float64: 2.5, Complex64: 1 + 2i, Complex128: 3 + 4i,
+		Bool: true, Label: "label",
+		Labels:   NamedLabels{"a", "b"},
+		Scores:   NamedScores{"a": 0.5},
+		Pair:     NamedPair{1, 2},
+		Ref:      &label,
+		Callback: func() int { return 7 },
+		Events:   make(NamedEvents, 1),
+		Any:      NamedSeverity(5),
+	}
+}
+
+func TestCloneNamedTypesTopLevel(t *testing.T) {
+	cloner := cloning.NewCloner()
+	model := newNamedModel()
+	value := reflect.ValueOf(model).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		clone := cloner.Clone(field.Interface())
+		if reflect.TypeOf(clone) != field.Type() && field.Kind() != reflect.Interface {
+			log.Fail(t, "Expected clone of ", value.Type().Field(i).Name, " to keep type ", field.Type().String(),
+				" but got ", reflect.TypeOf(clone).String())
+			return
+		}
+	}
+}
+
+func TestCloneNamedTypesInStruct(t *testing.T) {
+	model := newNamedModel()
+	clone := cloning.NewCloner().Clone(model).(*NamedModel)
+	if clone.Int != -1 || clone.Int8 != -8 || clone.Int16 != -16 || clone.Severity != 3 || clone.Int64 != -64 {
+		log.Fail(t, "Expected signed named values to be cloned")
+		return
+	}
+	if clone.Uint != 1 || clone.Uint8 != 8 || clone.Uint16 != 16 || clone.Uint32 != 32 || clone.Uint64 != 64 {
+		log.Fail(t, "Expected unsigned named values to be cloned")
+		return
+	}
+	if clone.Float32 != 1.5 || clone.Float64 != 2.5 || clone.Complex64 != 1+2i || clone.Complex128 != 3+4i {
+		log.Fail(t, "Expected float and complex named values to be cloned")
+		return
+	}
+	if !clone.Bool || clone.Label != "label" || clone.Pair != model.Pair {
+		log.Fail(t, "Expected bool, string and array named values to be cloned")
+		return
+	}
+	if len(clone.Labels) != 2 || clone.Labels[1] != "b" || &clone.Labels[0] == &model.Labels[0] {
+		log.Fail(t, "Expected named slice to be deep cloned")
+		return
+	}
+	if clone.Scores["a"] != 0.5 || reflect.ValueOf(clone.Scores).Pointer() == reflect.ValueOf(model.Scores).Pointer() {
+		log.Fail(t, "Expected named map to be deep cloned")
+		return
+	}
+	if clone.Ref == model.Ref || *clone.Ref != "ref" {
+		log.Fail(t, "Expected named pointer to be deep cloned")
+		return
+	}
+	if clone.Callback() != 7 || clone.Events == nil || clone.Events == model.Events {
+		log.Fail(t, "Expected named func and chan to be cloned")
+		return
+	}
+	if severity, ok := clone.Any.(NamedSeverity); !ok || severity != 5 {
+		log.Fail(t, "Expected named value inside an interface to keep its type")
+		return
+	}
+}
@@ -0,0 +1,107 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+)
+
+type ParallelNode struct {
+	Name   string
+	Values []int64
+	Parent *ParallelInventory
+	Peer   *ParallelNode
+}
+
+type ParallelInventory struct {
+	Nodes  []*ParallelNode
+	ByName map[string]*ParallelNode
+	Shared *ParallelNode
+}
+
+func newParallelInventory(size int) *ParallelInventory {
+	inventory := &ParallelInventory{ByName: make(map[string]*ParallelNode)}
+	inventory.Shared = &ParallelNode{Name: "shared", Parent: inventory}
+	for i := 0; i < size; i++ {
+		node := &ParallelNode{Name: "node" + strconv.Itoa(i), Values: []int64{int64(i), int64(i * 2)}, Parent: inventory}
+		node.Peer = inventory.Shared
+		inventory.Nodes = append(inventory.Nodes, node)
+		inventory.ByName[node.Name] = node
+	}
+	return inventory
+}
+
+func TestCloneParallel(t *testing.T) {
+	orig := newParallelInventory(5000)
+	cloner := cloning.NewCloner()
+	cloner.SetParallel(64, 4)
+	clone := cloner.Clone(orig).(*ParallelInventory)
+	expected := cloning.NewCloner().Clone(orig).(*ParallelInventory)
+	if len(clone.Nodes) != len(expected.Nodes) || len(clone.ByName) != len(expected.ByName) {
+		log.Fail(t, "Expected parallel clone to have the size of the sequential clone")
+		return
+	}
+	for i, node := range clone.Nodes {
+		if node.Name != expected.Nodes[i].Name || !reflect.DeepEqual(node.Values, expected.Nodes[i].Values) {
+			log.Fail(t, "Expected node ", i, " to be equal to the sequential clone")
+			return
+		}
+		if node == orig.Nodes[i] {
+			log.Fail(t, "Expected node ", i, " to be cloned")
+			return
+		}
+		if node.Parent != clone || node.Peer != clone.Shared {
+			log.Fail(t, "Expected node ", i, " to reference the cloned inventory and shared node")
+			return
+		}
+		if clone.ByName[node.Name] != node {
+			log.Fail(t, "Expected map and slice to share the cloned node ", node.Name)
+			return
+		}
+	}
+}
+
+func TestCloneParallelConcurrentCalls(t *testing.T) {
+	orig := newParallelInventory(1000)
+	cloner := cloning.NewCloner()
+	cloner.SetParallel(100, 2)
+	done := make(chan *ParallelInventory, 4)
+	for i := 0; i < 4; i++ {
+		go func() {
+			done <- cloner.Clone(orig).(*ParallelInventory)
+		}()
+	}
+	for i := 0; i < 4; i++ {
+		clone := <-done
+		if len(clone.Nodes) != 1000 || clone.Nodes[999].Parent != clone {
+			log.Fail(t, "Expected each concurrent clone to be complete")
+			return
+		}
+	}
+}
+
+func TestCloneParallelDisabled(t *testing.T) {
+	orig := newParallelInventory(10)
+	cloner := cloning.NewCloner()
+	cloner.SetParallel(0, 4)
+	clone := cloner.Clone(orig).(*ParallelInventory)
+	if clone.Nodes[0].Parent != clone || clone.ByName["node0"] != clone.Nodes[0] {
+		log.Fail(t, "Expected a sequential clone to keep the references")
+		return
+	}
+}
@@ -0,0 +1,188 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+	"github.com/saichler/l8reflect/go/reflect/properties"
+	"github.com/saichler/l8reflect/go/reflect/updating"
+	"github.com/saichler/l8types/go/ifs"
+)
+
+type OptionalModel struct {
+	Id      string
+	Tags    *[]string
+	Labels  *map[string]string
+	Entries *map[string]*KeyedEntry
+}
+
+func newOptionalResources() ifs.IResources {
+	return newLocalResources(map[interface{}]string{&OptionalModel{}: "Id"})
+}
+
+func TestPointerContainerIntrospect(t *testing.T) {
+	res := newOptionalResources()
+	node, ok := res.Introspector().Node("optionalmodel.tags")
+	if !ok || !node.IsSlice {
+		log.Fail(t, "Expected pointer to slice to be an optional slice node")
+		return
+	}
+	node, ok = res.Introspector().Node("optionalmodel.labels")
+	if !ok || !node.IsMap {
+		log.Fail(t, "Expected pointer to map to be an optional map node")
+		return
+	}
+	_, ok = res.Introspector().Node("optionalmodel.entries.name")
+	if !ok {
+		log.Fail(t, "Expected pointer to map of structs to be inspected")
+		return
+	}
+}
+
+func TestPointerContainerGet(t *testing.T) {
+	res := newOptionalResources()
+	model := &OptionalModel{Id: "o1"}
+	prop, err := properties.PropertyOf("optionalmodel.tags", res)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	v, err := prop.Get(model)
+	if err != nil || v != nil {
+		log.Fail(t, "Expected nil for an absent container")
+		return
+	}
+	tags := []string{"a", "b"}
+	model.Tags = &tags
+	v, err = prop.Get(model)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	list, ok := v.([]string)
+	if !ok || len(list) != 2 {
+		log.Fail(t, "Expected the pointer to slice to be dereferenced")
+		return
+	}
+}
+
+func TestPointerContainerSetAllocates(t *testing.T) {
+	res := newOptionalResources()
+	model := &OptionalModel{Id: "o1"}
+
+	prop, err := properties.PropertyOf("optionalmodel.labels<{24}env>", res)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	_, _, err = prop.Set(model, "prod")
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if model.Labels == nil || (*model.Labels)["env"] != "prod" {
+		log.Fail(t, "Expected the map pointer to be allocated on demand")
+		return
+	}
+
+	prop, err = properties.PropertyOf("optionalmodel.entries<{24}e1>.name", res)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	_, _, err = prop.Set(model, "entry")
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if model.Entries == nil || (*model.Entries)["e1"] == nil || (*model.Entries)["e1"].Name != "entry" {
+		log.Fail(t, "Expected the map of structs pointer to be allocated on demand")
+		return
+	}
+}
+
+func TestPointerContainerUpdaterAbsentVsEmpty(t *testing.T) {
+	res := newOptionalResources()
+	aside := &OptionalModel{Id: "o1"}
+	zside := &OptionalModel{Id: "o1", Labels: &map[string]string{}}
+	yside := &OptionalModel{Id: "o1"}
+
+	upd := updating.NewUpdater(res, false, false)
+	err := upd.Update(aside, zside)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if len(upd.Changes()) != 1 {
+		log.Fail(t, "Expected absent to empty to be 1 change but got ", len(upd.Changes()))
+		return
+	}
+	if aside.Labels == nil || len(*aside.Labels) != 0 {
+		log.Fail(t, "Expected old labels to become an empty map")
+		return
+	}
+	upd.Changes()[0].Apply(yside)
+	if !cloning.NewDeepEqual().Equal(yside, zside) {
+		log.Fail(t, "Expected absent to empty change to apply")
+		return
+	}
+
+	zside = &OptionalModel{Id: "o1"}
+	upd = updating.NewUpdater(res, true, false)
+	err = upd.Update(aside, zside)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if len(upd.Changes()) != 1 {
+		log.Fail(t, "Expected empty to absent to be 1 change but got ", len(upd.Changes()))
+		return
+	}
+	if aside.Labels != nil {
+		log.Fail(t, "Expected old labels to become absent")
+		return
+	}
+	upd.Changes()[0].Apply(yside)
+	if yside.Labels != nil {
+		log.Fail(t, "Expected empty to absent change to apply")
+		return
+	}
+}
+
+func TestPointerContainerUpdaterEntries(t *testing.T) {
+	res := newOptionalResources()
+	aside := &OptionalModel{Id: "o1", Entries: &map[string]*KeyedEntry{"e1": {Name: "old"}}}
+	zside := &OptionalModel{Id: "o1", Entries: &map[string]*KeyedEntry{"e1": {Name: "new"}, "e2": {Name: "added"}}}
+	yside := cloning.NewCloner().Clone(aside).(*OptionalModel)
+
+	upd := updating.NewUpdater(res, false, false)
+	err := upd.Update(aside, zside)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if len(upd.Changes()) != 2 {
+		log.Fail(t, "Expected 2 changes but got ", len(upd.Changes()))
+		return
+	}
+	for _, change := range upd.Changes() {
+		change.Apply(yside)
+	}
+	if !cloning.NewDeepEqual().Equal(yside, zside) {
+		log.Fail(t, "Expected entry changes to apply through the map pointer")
+		return
+	}
+}
@@ -255,3 +255,81 @@ func TestSubStructProperty(t *testing.T) {
 	}
 	fmt.Println(yside)
 }
+
+type PathRate struct {
+	Name  string
+	Value float64
+}
+
+type PathModel struct {
+	Id      string
+	Rates   map[string]*PathRate
+	Weights map[float64]string
+}
+
+func TestPropertyPathSegments(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{&PathModel{}: "Id"})
+	model := &PathModel{
+		Id:      "p1",
+		Rates:   map[string]*PathRate{"a.b": {Name: "dotted", Value: 1}},
+		Weights: map[float64]string{1.5: "one"},
+	}
+
+	prop, err := properties.PropertyOf("pathmodel<{24}p1>.rates<{24}a.b>.name", res)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	v, err := prop.Get(model)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if v != "dotted" {
+		log.Fail(t, "Expected a dot inside a key not to split the path but got ", v)
+		return
+	}
+	parent := prop.Parent()
+	if parent == nil || parent.Key() != "a.b" {
+		log.Fail(t, "Expected the parent property to hold the dotted key")
+		return
+	}
+	if parent.Parent() == nil || parent.Parent().Key() != "p1" {
+		log.Fail(t, "Expected the root property to hold its key")
+		return
+	}
+	id, err := prop.PropertyId()
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if id != "pathmodel<{24}p1>.rates<{24}a.b>.name" {
+		log.Fail(t, "Expected the property id to round trip but got ", id)
+		return
+	}
+
+	prop, err = properties.PropertyOf("pathmodel.weights<{14}1.5>", res)
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if prop.Key() != 1.5 {
+		log.Fail(t, "Expected the float key 1.5 but got ", prop.Key())
+		return
+	}
+	_, _, err = prop.Set(model, "updated")
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if model.Weights[1.5] != "updated" {
+		log.Fail(t, "Expected the float keyed value to be updated")
+		return
+	}
+
+	_, err = properties.PropertyOf("pathmodel.rates<{24}a.name", res)
+	if err == nil {
+		log.Fail(t, "Expected an error for an unclosed key")
+		return
+	}
+}
@@ -0,0 +1,180 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+	"github.com/saichler/l8reflect/go/reflect/helping"
+	"github.com/saichler/l8reflect/go/reflect/introspecting"
+)
+
+type RedactCredential struct {
+	User   string
+	ApiKey string
+	Pin    string
+	Seed   []byte
+	Level  int32
+}
+
+type RedactAccount struct {
+	Id          string
+	Password    string
+	Tokens      []string
+	Secrets     map[string]string
+	Credentials []*RedactCredential
+	ByUser      map[string]*RedactCredential
+}
+
+type RedactVault struct {
+	Name  string
+	Pin   *string
+	Codes *[]string
+	Any   interface{}
+}
+
+func newRedactAccount() *RedactAccount {
+	cred := &RedactCredential{User: "u1", ApiKey: "key", Pin: "1234", Seed: []byte{1, 2}, Level: 3}
+	return &RedactAccount{
+		Id:          "a1",
+		Password:    "pass",
+		Tokens:      []string{"t1", "t2"},
+		Secrets:     map[string]string{"db": "dbpass"},
+		Credentials: []*RedactCredential{cred},
+		ByUser:      map[string]*RedactCredential{"u1": cred},
+	}
+}
+
+func newRedactCloner(t *testing.T) *cloning.Cloner {
+	res := newLocalResources(map[interface{}]string{&RedactAccount{}: "Id", &RedactCredential{}: "User"})
+	in := res.Introspector().(*introspecting.Introspector)
+	err := in.AddSensitiveDecorator(&RedactCredential{}, "ApiKey", "Pin", "Seed", "Level")
+	if err != nil {
+		log.Fail(t, err.Error())
+		return nil
+	}
+	cloner := cloning.NewCloner()
+	cloner.SetRedactPolicy("***", in.SensitiveDecoratorPolicy(), helping.NewNamePolicy("Password", "Tokens", "Secrets"))
+	return cloner
+}
+
+func TestRedactClone(t *testing.T) {
+	cloner := newRedactCloner(t)
+	if cloner == nil {
+		return
+	}
+	account := newRedactAccount()
+	clone := cloner.Clone(account).(*RedactAccount)
+	if clone.Id != "a1" || clone.Password != "***" {
+		log.Fail(t, "Expected password to be redacted")
+		return
+	}
+	if len(clone.Tokens) != 2 || clone.Tokens[1] != "***" || clone.Secrets["db"] != "***" || len(clone.Secrets) != 1 {
+		log.Fail(t, "Expected redacted containers to keep their shape and keys")
+		return
+	}
+	cred := clone.Credentials[0]
+	if cred.User != "u1" || cred.ApiKey != "***" || cred.Pin != "***" || cred.Level != 0 {
+		log.Fail(t, "Expected sensitive fields inside slices to be redacted")
+		return
+	}
+	if len(cred.Seed) != 2 || cred.Seed[0] != 0 {
+		log.Fail(t, "Expected sensitive bytes to be zeroed")
+		return
+	}
+	if clone.ByUser["u1"] != cred {
+		log.Fail(t, "Expected shared pointers to stay shared")
+		return
+	}
+	if account.Password != "pass" || account.Credentials[0].Pin != "1234" || account.Tokens[0] != "t1" {
+		log.Fail(t, "Expected the original not to be modified")
+		return
+	}
+}
+
+func TestRedactMaskAndCopyInto(t *testing.T) {
+	cloner := newRedactCloner(t)
+	if cloner == nil {
+		return
+	}
+	masked, err := cloner.CloneMask(newRedactAccount(), "redactaccount.credentials.apikey", "redactaccount.password")
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if masked.(*RedactAccount).Password != "***" || masked.(*RedactAccount).Credentials[0].ApiKey != "***" {
+		log.Fail(t, "Expected CloneMask to redact")
+		return
+	}
+	dst := &RedactAccount{}
+	err = cloner.CopyInto(dst, newRedactAccount())
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	if dst.Password != "***" || dst.ByUser["u1"].ApiKey != "***" {
+		log.Fail(t, "Expected CopyInto to redact")
+		return
+	}
+	cloner.SetRedactPolicy("")
+	if cloner.Clone(newRedactAccount()).(*RedactAccount).Password != "pass" {
+		log.Fail(t, "Expected redaction to be turned off")
+		return
+	}
+}
+
+func TestRedactPointersAndInterfaces(t *testing.T) {
+	pin := "1234"
+	codes := []string{"c1"}
+	cloner := cloning.NewCloner()
+	cloner.SetRedactPolicy("<redacted>", helping.NewNamePolicy("Pin", "Codes", "Any"))
+	clone := cloner.Clone(&RedactVault{Name: "v", Pin: &pin, Codes: &codes, Any: "secret"}).(*RedactVault)
+	if clone.Name != "v" || clone.Pin == &pin || *clone.Pin != "<redacted>" {
+		log.Fail(t, "Expected pointed-to string to be redacted")
+		return
+	}
+	if (*clone.Codes)[0] != "<redacted>" || clone.Any != "<redacted>" || pin != "1234" || codes[0] != "c1" {
+		log.Fail(t, "Expected pointed-to slice and interface string to be redacted")
+		return
+	}
+}
+
+// RedactGenHolder nests a type with a generated DeepClone in a plain struct.
+type RedactGenHolder struct {
+	Device *GenDevice
+}
+
+func TestRedactGeneratedTypes(t *testing.T) {
+	cloner := cloning.NewCloner()
+	cloner.SetRedactPolicy("***", helping.NewNamePolicy("Vendor"))
+	clone := cloner.Clone(newGenDevice()).(*GenDevice)
+	if clone.Info.Vendor != "***" || clone.Location.Vendor != "***" {
+		log.Fail(t, "Expected the fields of a generated type to be redacted")
+		return
+	}
+	holder := cloner.Clone(&RedactGenHolder{Device: newGenDevice()}).(*RedactGenHolder)
+	if holder.Device.Info.Vendor != "***" || holder.Device.Ports[0].Name != "eth0" {
+		log.Fail(t, "Expected the fields of a nested generated type to be redacted")
+		return
+	}
+
+	cloner = cloning.NewCloner()
+	cloner.SetFieldPolicy(helping.NewNamePolicy("Tags"))
+	clone = cloner.Clone(newGenDevice()).(*GenDevice)
+	if clone.Tags != nil || clone.Id != "d1" {
+		log.Fail(t, "Expected the field policy to apply to a generated type")
+		return
+	}
+}
@@ -0,0 +1,157 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+)
+
+type SizeLeaf struct {
+	Name string
+	Data []byte
+}
+
+type SizeTree struct {
+	Id      string
+	Primary *SizeLeaf
+	Backup  *SizeLeaf
+	Leaves  []*SizeLeaf
+	Index   map[string]*SizeLeaf
+}
+
+type SizeView struct {
+	Leaf *SizeLeaf
+	Name *string
+	Data []byte
+}
+
+type SizeViewFirst struct {
+	Name *string
+	Data []byte
+	Leaf *SizeLeaf
+}
+
+type SizeNode struct {
+	A    int64
+	Self *SizeNode
+}
+
+type SizeHolder struct {
+	Inner *int64
+	N     *SizeNode
+}
+
+func TestSizeLeaf(t *testing.T) {
+	leaf := &SizeLeaf{Name: "abcd", Data: make([]byte, 10, 16)}
+	// pointer + struct (string and slice headers) + string data + backing array capacity
+	expected := int64(8 + 16 + 24 + 4 + 16)
+	if cloning.Size(leaf) != expected {
+		log.Fail(t, "Expected size ", expected, " got ", cloning.Size(leaf))
+		return
+	}
+}
+
+func TestSizeSharedPointers(t *testing.T) {
+	leaf := &SizeLeaf{Name: "abcd", Data: make([]byte, 10, 16)}
+	leafSize := cloning.Size(leaf) - 8
+	shared := &SizeTree{Primary: leaf, Backup: leaf}
+	separate := &SizeTree{Primary: leaf, Backup: &SizeLeaf{Name: "abcd", Data: make([]byte, 10, 16)}}
+	if cloning.Size(separate)-cloning.Size(shared) != leafSize {
+		log.Fail(t, "Expected a shared pointer to be counted once")
+		return
+	}
+	other := &SizeLeaf{Name: "abcd", Data: make([]byte, 10, 16)}
+	withShared := cloning.Size(&SizeTree{Leaves: []*SizeLeaf{leaf, leaf}, Index: map[string]*SizeLeaf{"a": leaf}})
+	withOther := cloning.Size(&SizeTree{Leaves: []*SizeLeaf{leaf, other}, Index: map[string]*SizeLeaf{"a": other}})
+	if withOther-withShared != leafSize {
+		log.Fail(t, "Expected pointers shared by slices and maps to be counted once")
+		return
+	}
+}
+
+func TestSizeByPath(t *testing.T) {
+	tree := &SizeTree{Id: "t1", Index: make(map[string]*SizeLeaf)}
+	for i := 0; i < 10; i++ {
+		leaf := &SizeLeaf{Name: "leaf", Data: make([]byte, 1000)}
+		tree.Leaves = append(tree.Leaves, leaf)
+	}
+	tree.Primary = &SizeLeaf{Name: "p"}
+	total, paths := cloning.SizeByPath(tree, 2)
+	if total != cloning.Size(tree) || paths["sizetree"] != total {
+		log.Fail(t, "Expected the root path to hold the total size")
+		return
+	}
+	if paths["sizetree.leaves"] < 10000 || paths["sizetree.leaves.data"] < 10000 || paths["sizetree.leaves.data"] > paths["sizetree.leaves"] {
+		log.Fail(t, "Expected the leaves data to dominate the leaves")
+		return
+	}
+	if paths["sizetree.id"] != 16+2 || paths["sizetree.primary"] != 8+40+1 {
+		log.Fail(t, "Unexpected field sizes ", paths["sizetree.id"], " ", paths["sizetree.primary"])
+		return
+	}
+	if _, ok := paths["sizetree.primary.data"]; !ok {
+		log.Fail(t, "Expected the breakdown to reach the second level")
+		return
+	}
+	_, paths = cloning.SizeByPath(tree, 1)
+	if _, ok := paths["sizetree.leaves.data"]; ok {
+		log.Fail(t, "Expected the breakdown to stop at the max depth")
+		return
+	}
+}
+
+func TestSizeInteriorPointers(t *testing.T) {
+	leaf := &SizeLeaf{Name: "abcd", Data: make([]byte, 10, 16)}
+	expected := cloning.Size(&SizeView{Leaf: leaf})
+	size := cloning.Size(&SizeView{Leaf: leaf, Name: &leaf.Name, Data: leaf.Data[2:]})
+	if size != expected {
+		log.Fail(t, "Expected a field pointer and a subslice of a counted leaf to add nothing, got ", size, " vs ", expected)
+		return
+	}
+	size = cloning.Size(&SizeViewFirst{Leaf: leaf, Name: &leaf.Name, Data: leaf.Data[2:]})
+	if size != expected {
+		log.Fail(t, "Expected a leaf counted after a field pointer and a subslice into it to be counted once, got ", size, " vs ", expected)
+		return
+	}
+	data := make([]byte, 10, 16)
+	size = cloning.Size(&SizeViewFirst{Data: data[4:], Leaf: &SizeLeaf{Data: data}})
+	expected = cloning.Size(&SizeViewFirst{Leaf: &SizeLeaf{Data: data}})
+	if size != expected {
+		log.Fail(t, "Expected a backing array shared by a subslice to be counted once, got ", size, " vs ", expected)
+		return
+	}
+}
+
+func TestSizeCycles(t *testing.T) {
+	node := &SizeNode{A: 1}
+	node.Self = node
+	// pointer + node
+	if cloning.Size(node) != 8+16 {
+		log.Fail(t, "Expected a self referencing node to be counted once, got ", cloning.Size(node))
+		return
+	}
+	// pointer + holder + node
+	expected := int64(8 + 16 + 16)
+	size := cloning.Size(&SizeHolder{Inner: &node.A, N: node})
+	if size != expected {
+		log.Fail(t, "Expected a cyclic node reached through an interior pointer to be counted once, got ", size)
+		return
+	}
+	if cloning.Size(&SizeHolder{N: node}) != expected {
+		log.Fail(t, "Expected the interior pointer to add nothing")
+		return
+	}
+}
@@ -0,0 +1,153 @@
+// © 2025 Sharon Aicler (saichler@gmail.com)
+//
+// Layer 8 Ecosystem is licensed under the Apache License, Version 2.0.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/saichler/l8reflect/go/reflect/cloning"
+	"github.com/saichler/l8reflect/go/reflect/introspecting"
+)
+
+type OrderIface struct {
+	Name  string
+	Speed int64
+}
+
+type OrderDevice struct {
+	Id         string
+	Interfaces []*OrderIface
+	Tags       []string
+	Path       []string
+}
+
+func newOrderDevice() *OrderDevice {
+	return &OrderDevice{
+		Id:         "d1",
+		Interfaces: []*OrderIface{{Name: "eth0", Speed: 1}, {Name: "eth1", Speed: 10}, {Name: "eth2", Speed: 10}},
+		Tags:       []string{"core", "edge", "core"},
+		Path:       []string{"a", "b"},
+	}
+}
+
+func newOrderDeepEqual(t *testing.T) *cloning.DeepEqual {
+	res := newLocalResources(map[interface{}]string{&OrderDevice{}: "Id", &OrderIface{}: "Name"})
+	in := res.Introspector().(*introspecting.Introspector)
+	err := in.AddSetDecorator(&OrderDevice{}, "Tags")
+	if err != nil {
+		log.Fail(t, err.Error())
+		return nil
+	}
+	deepEqual := cloning.NewDeepEqual()
+	deepEqual.SetSliceOrderPolicy(in.SliceOrderDecoratorPolicy())
+	return deepEqual
+}
+
+func TestUnorderedEqualReordered(t *testing.T) {
+	deepEqual := newOrderDeepEqual(t)
+	if deepEqual == nil {
+		return
+	}
+	zSide := newOrderDevice()
+	zSide.Interfaces[0], zSide.Interfaces[2] = zSide.Interfaces[2], zSide.Interfaces[0]
+	zSide.Tags = []string{"edge", "core", "core"}
+	if !deepEqual.Equal(newOrderDevice(), zSide) {
+		log.Fail(t, "Expected reordered keyed list and set to be equal")
+		return
+	}
+	if cloning.NewDeepEqual().Equal(newOrderDevice(), zSide) {
+		log.Fail(t, "Expected reordered slices to differ without the policy")
+		return
+	}
+	zSide.Path = []string{"b", "a"}
+	if deepEqual.Equal(newOrderDevice(), zSide) {
+		log.Fail(t, "Expected slices without decorators to stay ordered")
+		return
+	}
+}
+
+func TestUnorderedEqualMultiset(t *testing.T) {
+	deepEqual := newOrderDeepEqual(t)
+	if deepEqual == nil {
+		return
+	}
+	zSide := newOrderDevice()
+	zSide.Tags = []string{"core", "edge", "edge"}
+	if deepEqual.Equal(newOrderDevice(), zSide) {
+		log.Fail(t, "Expected sets with different multiplicities to differ")
+		return
+	}
+}
+
+func TestUnorderedEqualByKey(t *testing.T) {
+	deepEqual := newOrderDeepEqual(t)
+	if deepEqual == nil {
+		return
+	}
+	zSide := newOrderDevice()
+	zSide.Interfaces = []*OrderIface{{Name: "eth2", Speed: 10}, {Name: "eth1", Speed: 100}, {Name: "eth3", Speed: 1}}
+	differences := deepEqual.EqualReport(newOrderDevice(), zSide, 0)
+	ids := reportIds(differences)
+	if len(differences) != 3 {
+		log.Fail(t, "Expected 3 differences, got ", len(differences))
+		return
+	}
+	if ids["orderdevice.interfaces<{2}1>.speed"] == nil {
+		log.Fail(t, "Expected the changed speed to be reported on the element with the same key")
+		return
+	}
+	removed := ids["orderdevice.interfaces<{2}0>"]
+	if removed == nil || removed.ASide.(*OrderIface).Name != "eth0" || removed.ZSide != nil {
+		log.Fail(t, "Expected the removed element to be reported")
+		return
+	}
+	added := ids["orderdevice.interfaces<{2}2>"]
+	if added == nil || added.ASide != nil || added.ZSide.(*OrderIface).Name != "eth3" {
+		log.Fail(t, "Expected the added element to be reported")
+		return
+	}
+}
+
+type OrderReading struct {
+	Id     string
+	Values []float64
+}
+
+func TestUnorderedEqualTolerance(t *testing.T) {
+	res := newLocalResources(map[interface{}]string{&OrderReading{}: "Id"})
+	in := res.Introspector().(*introspecting.Introspector)
+	err := in.AddSetDecorator(&OrderReading{}, "Values")
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	deepEqual := cloning.NewDeepEqual()
+	deepEqual.SetSliceOrderPolicy(in.SliceOrderDecoratorPolicy())
+	err = deepEqual.SetOptions(&cloning.EqualOptions{FloatAbsTolerance: 0.15})
+	if err != nil {
+		log.Fail(t, err.Error())
+		return
+	}
+	aSide := &OrderReading{Id: "r1", Values: []float64{1.0, 1.1}}
+	zSide := &OrderReading{Id: "r1", Values: []float64{1.1, 0.9}}
+	if !deepEqual.Equal(aSide, zSide) {
+		log.Fail(t, "Expected 1.0 to pair with 0.9 when 1.1 is taken by the other element")
+		return
+	}
+	zSide.Values = []float64{1.1, 1.3}
+	if deepEqual.Equal(aSide, zSide) {
+		log.Fail(t, "Expected sets without a full pairing within tolerance to differ")
+		return
+	}
+}